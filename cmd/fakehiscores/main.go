@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"misclicked-events/internal/fakehiscore"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8081", "address to listen on")
+	fixturePath := flag.String("fixture", "", "path to a fixture JSON file")
+	tick := flag.Duration("tick", 0, "real time between simulated steps, 0 to only advance via /_fake/advance")
+	step := flag.Duration("step", time.Hour, "simulated time to advance every tick")
+	flag.Parse()
+
+	fixture := fakehiscore.Fixture{}
+	if *fixturePath != "" {
+		var err error
+		fixture, err = fakehiscore.LoadFixture(*fixturePath)
+		if err != nil {
+			fmt.Println("Error loading fixture,", err)
+			os.Exit(1)
+		}
+	}
+
+	srv := fakehiscore.New(fixture)
+
+	if *tick > 0 {
+		go func() {
+			ticker := time.NewTicker(*tick)
+			defer ticker.Stop()
+			for range ticker.C {
+				srv.Advance(*step)
+			}
+		}()
+	}
+
+	fmt.Printf("Fake hiscores listening on http://%s (set HISCORE_BASE_URL to use them)\n", *addr)
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		fmt.Println("Error running fake hiscores,", err)
+		os.Exit(1)
+	}
+}
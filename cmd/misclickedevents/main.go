@@ -8,13 +8,20 @@ import (
 
 	"misclicked-events/internal/commands"
 	"misclicked-events/internal/config"
+	"misclicked-events/internal/dashboard"
+	"misclicked-events/internal/data"
 	"misclicked-events/internal/handlers"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/service"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 func main() {
 	token := config.GetToken()
+	service.SetBaseURL(config.GetHiscoreBaseURL())
+	i18n.SetGuildLocaleLookup(data.GetGuildLocale)
+
 	dg, err := discordgo.New("Bot " + token)
 	if err != nil {
 		fmt.Println("Error creating Discord session,", err)
@@ -31,6 +38,19 @@ func main() {
 
 	commands.RegisterCommands(dg, false)
 
+	// The hiscore updates below never return, so start the dashboard first
+	if addr := config.GetDashboardAddr(); addr != "" {
+		go func() {
+			names := func(guildID, discordId string) string {
+				return commands.DisplayName(dg, guildID, discordId)
+			}
+			fmt.Printf("Dashboard listening on http://%s\n", addr)
+			if err := dashboard.ListenAndServe(addr, names); err != nil {
+				fmt.Println("Error running dashboard,", err)
+			}
+		}()
+	}
+
 	commands.UpdateBOTMHiscores(dg)
 
 	fmt.Println("Bot is now running. Press CTRL+C to exit.")
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// AccountClaimButtonPrefix starts the custom ID of the buttons of the
+// account claim flow.
+const AccountClaimButtonPrefix = "account_claim"
+
+// respondWithOwnedAccount tells the member who tracks the account already and
+// offers to claim it.
+func respondWithOwnedAccount(s *discordgo.Session, i *discordgo.InteractionCreate, owned *data.AccountOwnedError) {
+	p := i18n.ForGuild(i.GuildID)
+
+	content := p.Sprintf(
+		"⚠️ **%s** is already tracked by <@%s>, an account can only count for one member.\n\n"+
+			"If the account is yours, claim it and an admin will settle who it belongs to.",
+		owned.AccountName, owned.OwnerId,
+	)
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+		Components: &[]discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    p.Sprintf("Claim this account"),
+						Emoji:    &discordgo.ComponentEmoji{Name: "🙋"},
+						Style:    discordgo.PrimaryButton,
+						CustomID: fmt.Sprintf("%s:open:%s", AccountClaimButtonPrefix, owned.AccountName),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+}
+
+func HandleAccountClaimButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	p := i18n.ForGuild(i.GuildID)
+
+	// Custom IDs look like account_claim:open:<account name> for members and
+	// account_claim:<grant|keep>:<claim id> for admins
+	parts := strings.SplitN(i.MessageComponentData().CustomID, ":", 3)
+	if len(parts) != 3 {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("unknown claim button")))
+		return
+	}
+
+	switch parts[1] {
+	case "open":
+		openAccountClaim(s, i, parts[2])
+	case "grant", "keep":
+		resolveAccountClaim(s, i, parts[2], parts[1] == "grant")
+	default:
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("unknown claim button")))
+	}
+}
+
+// openAccountClaim puts the member's claim in front of the admins.
+func openAccountClaim(s *discordgo.Session, i *discordgo.InteractionCreate, accountName string) {
+	p := i18n.ForGuild(i.GuildID)
+
+	config, err := data.GetBotConfig(i.GuildID)
+	if err != nil || config.AdminChannelID == "" {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("there is no admin channel to send the claim to, please ask an admin directly")))
+		return
+	}
+
+	claim, err := data.CreateAccountClaim(i.GuildID, accountName, i.Member.User.ID)
+	if errors.Is(err, data.ErrClaimPending) {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("you already claimed this account, an admin will look at it soon")))
+		return
+	}
+	if err != nil {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("could not claim the account: %v", errorReason(p, err))))
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: p.Sprintf("🙋 Account claim"),
+		Color: 0xFFA500,
+		Description: p.Sprintf(
+			"<@%s> says **%s** is theirs, but it's tracked by <@%s>.\n\n"+
+				"Giving it to them moves the account and its event progress over.",
+			claim.ClaimantId, claim.AccountName, claim.OwnerId,
+		),
+		Timestamp: claim.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	_, err = s.ChannelMessageSendComplex(config.AdminChannelID, &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    p.Sprintf("Give to %s", DisplayName(s, i.GuildID, claim.ClaimantId)),
+						Style:    discordgo.SuccessButton,
+						CustomID: fmt.Sprintf("%s:grant:%s", AccountClaimButtonPrefix, claim.ID),
+					},
+					discordgo.Button{
+						Label:    p.Sprintf("Keep with %s", DisplayName(s, i.GuildID, claim.OwnerId)),
+						Style:    discordgo.SecondaryButton,
+						CustomID: fmt.Sprintf("%s:keep:%s", AccountClaimButtonPrefix, claim.ID),
+					},
+				},
+			},
+		},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		utils.LogError("Error sending account claim", err)
+
+		// Nobody will see the claim, so it mustn't block claiming again
+		if err := data.CancelAccountClaim(i.GuildID, claim.ID); err != nil {
+			utils.LogError("Error cancelling account claim", err)
+		}
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("could not send the claim to the admin channel, please try again later or ask an admin directly")))
+		return
+	}
+
+	utils.RespondWithPrivateMessage(s, i, "📨 Your claim on **%s** has been sent to the admins.", claim.AccountName)
+}
+
+// resolveAccountClaim settles a claim and lets the claimant know how it went.
+func resolveAccountClaim(s *discordgo.Session, i *discordgo.InteractionCreate, claimID string, grant bool) {
+	p := i18n.ForGuild(i.GuildID)
+
+	if !utils.IsAdmin(i) {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("you don't have the required permissions")))
+		return
+	}
+
+	claim, err := data.ResolveAccountClaim(i.GuildID, claimID, grant)
+	if err != nil {
+		utils.RespondWithError(s, i, errors.New(errorReason(p, err)))
+		return
+	}
+
+	verdict := p.Sprintf("Kept with the current owner")
+	if grant {
+		verdict = p.Sprintf("Given to the claimant")
+	}
+
+	embeds := i.Message.Embeds
+	if len(embeds) > 0 {
+		embeds[0].Color = 0x999999
+		embeds[0].Footer = &discordgo.MessageEmbedFooter{
+			Text: p.Sprintf("%s by %s", verdict, i.Member.User.Username),
+		}
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     embeds,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		utils.LogError("Error updating claim message", err)
+	}
+
+	outcome := &discordgo.MessageEmbed{
+		Title:       p.Sprintf("🙋 Account claim"),
+		Color:       0x999999,
+		Description: p.Sprintf("The admins decided **%s** stays with its current owner.", claim.AccountName),
+	}
+	if grant {
+		outcome.Color = 0x33cc33
+		outcome.Description = p.Sprintf("The admins gave you **%s**, it's tracked on your profile now.", claim.AccountName)
+
+		if ongoingEvent := checkOngoingEvent(i.GuildID); ongoingEvent != "" {
+			err = UpdateHiscoreMessage(s, i.GuildID)
+			if err != nil {
+				utils.LogError("Error updating hiscore message", err)
+			}
+		}
+	}
+
+	err = sendDirectMessage(s, claim.ClaimantId, outcome)
+	if err != nil {
+		utils.LogError(fmt.Sprintf("Error sending claim outcome to %s", claim.ClaimantId), err)
+	}
+}
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/service"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// adminMemberOption picks the member whose accounts an admin subcommand acts on
+var adminMemberOption = &discordgo.ApplicationCommandOption{
+	Type:        discordgo.ApplicationCommandOptionUser,
+	Name:        "user",
+	Description: "The member whose accounts to manage",
+	Required:    true,
+}
+
+var AdminCommand = &discordgo.ApplicationCommand{
+	Name:        "admin",
+	Description: "Manage the tracked accounts of other members",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "track",
+			Description: "Start tracking an OSRS account for a member",
+			Options: []*discordgo.ApplicationCommandOption{
+				adminMemberOption,
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "username",
+					Description: "The username to start tracking",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "untrack",
+			Description: "Stop tracking an OSRS account of a member",
+			Options: []*discordgo.ApplicationCommandOption{
+				adminMemberOption,
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "username",
+					Description:  "The OSRS account username to stop tracking",
+					Required:     true,
+					Autocomplete: true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "rename",
+			Description: "Rename a tracked OSRS account of a member",
+			Options: []*discordgo.ApplicationCommandOption{
+				adminMemberOption,
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "old_username",
+					Description:  "The current username of the account",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "new_username",
+					Description: "The new username to change to",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "transfer",
+			Description: "Move an OSRS account and its event progress to another member",
+			Options: []*discordgo.ApplicationCommandOption{
+				adminMemberOption,
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "username",
+					Description:  "The OSRS account username to move",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionUser,
+					Name:        "to",
+					Description: "The member to move the account to",
+					Required:    true,
+				},
+			},
+		},
+	},
+}
+
+func HandleAdminCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionsByName(subcommand.Options)
+	memberID := options["user"].Value.(string)
+
+	var err error
+	var response string
+	switch subcommand.Name {
+	case "track":
+		username := options["username"].StringValue()
+		err = data.TrackAccount(i.GuildID, username, memberID)
+		if err != nil {
+			return errors.New(p.Sprintf("could not track the account '%s': %v", username, errorReason(p, err)))
+		}
+		response = p.Sprintf("✅ Started tracking **%s** for <@%s>.", username, memberID)
+	case "untrack":
+		username := options["username"].StringValue()
+		err = data.UntrackAccount(i.GuildID, username, memberID)
+		if err != nil {
+			return errors.New(p.Sprintf("could not untrack the account '%s': %v", username, errorReason(p, err)))
+		}
+		response = p.Sprintf("✅ Stopped tracking **%s** for <@%s>.", username, memberID)
+	case "rename":
+		oldUsername := options["old_username"].StringValue()
+		newUsername := options["new_username"].StringValue()
+		if !service.CheckIfPlayerExists(newUsername) {
+			return errors.New(p.Sprintf("could not find an OSRS account with the username: %s", newUsername))
+		}
+		err = data.RenameAccount(i.GuildID, oldUsername, newUsername, memberID)
+		if err != nil {
+			return errors.New(p.Sprintf("could not rename the account: %v", errorReason(p, err)))
+		}
+		response = p.Sprintf("✅ Renamed **%s** to **%s** for <@%s>.", oldUsername, newUsername, memberID)
+	case "transfer":
+		username := options["username"].StringValue()
+		toID := options["to"].Value.(string)
+		err = data.TransferAccount(i.GuildID, username, memberID, toID)
+		if err != nil {
+			return errors.New(p.Sprintf("could not transfer the account '%s': %v", username, errorReason(p, err)))
+		}
+		response = p.Sprintf("✅ Moved **%s** from <@%s> to <@%s>.", username, memberID, toID)
+	}
+
+	// Update the hiscore message if there's an ongoing event
+	if ongoingEvent := checkOngoingEvent(i.GuildID); ongoingEvent != "" {
+		err = UpdateHiscoreMessage(s, i.GuildID)
+		if err != nil {
+			utils.LogError("Error updating hiscore message", err)
+		}
+	}
+
+	utils.EditResponseMessage(s, i, response)
+
+	return nil
+}
+
+// HandleAdminAccountAutocomplete suggests the accounts of the member picked
+// in the subcommand, once one is picked.
+func HandleAdminAccountAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	subcommand := i.ApplicationCommandData().Options[0]
+
+	typed := ""
+	memberID := ""
+	for _, option := range subcommand.Options {
+		if option.Focused {
+			typed = option.StringValue()
+		}
+		if option.Name == "user" {
+			memberID, _ = option.Value.(string)
+		}
+	}
+
+	// Only admins get to see other members' accounts
+	if !utils.IsAdmin(i) {
+		memberID = ""
+	}
+
+	respondWithAccountChoices(s, i, memberID, typed)
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/utils"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// maxAutocompleteChoices is the most suggestions Discord accepts at once
+const maxAutocompleteChoices = 25
+
+// HandleOwnAccountAutocomplete suggests the caller's own tracked accounts
+// matching what they typed so far.
+func HandleOwnAccountAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	typed := ""
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Focused {
+			typed = option.StringValue()
+		}
+	}
+
+	respondWithAccountChoices(s, i, i.Member.User.ID, typed)
+}
+
+// respondWithAccountChoices suggests the member's tracked accounts matching
+// what was typed so far.
+func respondWithAccountChoices(s *discordgo.Session, i *discordgo.InteractionCreate, discordId, typed string) {
+	// Members without tracked accounts simply get no suggestions
+	accounts, _ := data.TrackedAccounts(i.GuildID, discordId)
+	sort.Slice(accounts, func(a, b int) bool {
+		return accounts[a].Name < accounts[b].Name
+	})
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, account := range accounts {
+		if len(choices) == maxAutocompleteChoices {
+			break
+		}
+		if accountNameMatches(account.Name, typed) {
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  account.Name,
+				Value: account.Name,
+			})
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		utils.LogError("Error sending autocomplete choices", err)
+	}
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/render"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultChartTop is how many participants a chart shows when not asked otherwise
+const defaultChartTop = 5
+
+var (
+	minChartTop = 1.0
+	maxChartTop = 10.0
+)
+
+var ChartCommand = &discordgo.ApplicationCommand{
+	Name:        "chart",
+	Description: "Draw the KC over the event as a chart",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "top",
+			Description: "How many of the leading participants to show",
+			Required:    false,
+			MinValue:    &minChartTop,
+			MaxValue:    maxChartTop,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "Show the accounts of this member instead",
+			Required:    false,
+		},
+	},
+}
+
+func HandleChartCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	top := defaultChartTop
+	memberID := ""
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "top":
+			top = int(option.IntValue())
+		case "user":
+			memberID = option.UserValue(s).ID
+		}
+	}
+
+	// The snapshots stay around after an event ends, until the next one starts
+	snapshots, err := data.GetStandingsSnapshots(i.GuildID)
+	if err != nil {
+		utils.LogError("Error fetching standings snapshots", err)
+		return errors.New(p.Sprintf("something went wrong while fetching the KC history"))
+	}
+	if len(snapshots) == 0 {
+		return errors.New(p.Sprintf("there is no KC history to draw yet"))
+	}
+
+	var img *pageImage
+	if memberID != "" {
+		img = accountsChart(s, i.GuildID, snapshots, memberID)
+	} else {
+		img = participantsChart(s, i.GuildID, snapshots, top)
+	}
+	if img == nil {
+		return errors.New(p.Sprintf("something went wrong while drawing the chart"))
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Files: []*discordgo.File{img.file()},
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+
+	return nil
+}
+
+// participantsChart draws the KC over the event of the top participants, or
+// returns nil when drawing fails.
+func participantsChart(s *discordgo.Session, guildID string, snapshots []data.StandingsSnapshot, top int) *pageImage {
+	p := i18n.ForGuild(guildID)
+
+	var series []render.Series
+	for _, line := range data.ParticipantKCSeries(snapshots, top) {
+		series = append(series, chartSeries(DisplayName(s, guildID, line.Key), line))
+	}
+
+	return renderChart(render.Chart{
+		Title:    p.Sprintf("KC over the event"),
+		Subtitle: p.Sprintf("%s - top %d", snapshots[0].Activity, top),
+		Series:   series,
+		Empty:    p.Sprintf("Nobody has KC yet!"),
+	})
+}
+
+// accountsChart draws the KC over the event of each of a member's accounts,
+// or returns nil when drawing fails.
+func accountsChart(s *discordgo.Session, guildID string, snapshots []data.StandingsSnapshot, discordId string) *pageImage {
+	p := i18n.ForGuild(guildID)
+
+	var series []render.Series
+	for _, line := range data.AccountKCSeries(snapshots, discordId) {
+		series = append(series, chartSeries(line.Key, line))
+	}
+
+	return renderChart(render.Chart{
+		Title:    p.Sprintf("KC over the event"),
+		Subtitle: p.Sprintf("%s - accounts of %s", snapshots[0].Activity, DisplayName(s, guildID, discordId)),
+		Series:   series,
+		Empty:    p.Sprintf("No KC for this member yet!"),
+	})
+}
+
+func chartSeries(name string, line data.KCSeries) render.Series {
+	series := render.Series{Name: name}
+	for _, point := range line.Points {
+		series.Points = append(series.Points, render.ChartPoint{At: point.At, Value: point.KC})
+	}
+	return series
+}
+
+func renderChart(chart render.Chart) *pageImage {
+	png, err := render.RenderChart(chart)
+	if err != nil {
+		utils.LogError("Error rendering chart", err)
+		return nil
+	}
+
+	return &pageImage{Name: "chart.png", Data: png}
+}
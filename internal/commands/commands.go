@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"maps"
 	"misclicked-events/internal/utils"
 
 	"github.com/bwmarrin/discordgo"
@@ -9,14 +10,17 @@ import (
 
 func RegisterCommands(s *discordgo.Session, force bool) {
 
-	commands := []*discordgo.ApplicationCommand{
-		ConfigCommand,
-		TrackAccountCommand,
-		UntrackAccountCommand,
-		TrackedAccountsCommand,
-		StartActivityCommand,
-		EndActivityCommand,
-		RenameAccountCommand,
+	// Commands only make sense in a server, admins see the admin commands
+	dmPermission := false
+	commands := make([]*discordgo.ApplicationCommand, 0, len(registry))
+	for _, command := range registry {
+		command.Definition.DMPermission = &dmPermission
+		command.Definition.DefaultMemberPermissions = command.Permission.defaultMemberPermissions()
+		commands = append(commands, command.Definition)
+	}
+
+	for _, command := range commands {
+		localizeCommand(command)
 	}
 
 	existingCommands, err := s.ApplicationCommands(s.State.User.ID, "")
@@ -63,6 +67,18 @@ func commandsAreEqual(existing []*discordgo.ApplicationCommand, new []*discordgo
 			return false
 		}
 
+		// Compare who gets to see the command
+		if !int64PtrsAreEqual(newCmd.DefaultMemberPermissions, existingCmd.DefaultMemberPermissions) ||
+			!dmPermissionsAreEqual(newCmd.DMPermission, existingCmd.DMPermission) {
+			return false
+		}
+
+		// Compare translations
+		if !localizationPtrsAreEqual(newCmd.NameLocalizations, existingCmd.NameLocalizations) ||
+			!localizationPtrsAreEqual(newCmd.DescriptionLocalizations, existingCmd.DescriptionLocalizations) {
+			return false
+		}
+
 		// Compare options
 		if !optionsAreEqual(newCmd.Options, existingCmd.Options) {
 			return false
@@ -83,7 +99,10 @@ func optionsAreEqual(newOpts []*discordgo.ApplicationCommandOption, existingOpts
 		if newOpt.Name != existingOpt.Name ||
 			newOpt.Description != existingOpt.Description ||
 			newOpt.Type != existingOpt.Type ||
-			newOpt.Required != existingOpt.Required {
+			newOpt.Required != existingOpt.Required ||
+			newOpt.Autocomplete != existingOpt.Autocomplete ||
+			!maps.Equal(newOpt.NameLocalizations, existingOpt.NameLocalizations) ||
+			!maps.Equal(newOpt.DescriptionLocalizations, existingOpt.DescriptionLocalizations) {
 			return false
 		}
 
@@ -91,6 +110,11 @@ func optionsAreEqual(newOpts []*discordgo.ApplicationCommandOption, existingOpts
 		if !choicesAreEqual(newOpt.Choices, existingOpt.Choices) {
 			return false
 		}
+
+		// Compare the options of subcommands
+		if !optionsAreEqual(newOpt.Options, existingOpt.Options) {
+			return false
+		}
 	}
 
 	return true
@@ -104,10 +128,24 @@ func choicesAreEqual(newChoices []*discordgo.ApplicationCommandOptionChoice, exi
 	// Compare each choice
 	for i, newChoice := range newChoices {
 		existingChoice := existingChoices[i]
-		if newChoice.Name != existingChoice.Name || newChoice.Value != existingChoice.Value {
+		if newChoice.Name != existingChoice.Name || newChoice.Value != existingChoice.Value ||
+			!maps.Equal(newChoice.NameLocalizations, existingChoice.NameLocalizations) {
 			return false
 		}
 	}
 
 	return true
 }
+
+func int64PtrsAreEqual(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// dmPermissionsAreEqual compares DM permissions, Discord allows DMs when it
+// isn't set.
+func dmPermissionsAreEqual(a, b *bool) bool {
+	return (a == nil || *a) == (b == nil || *b)
+}
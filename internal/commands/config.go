@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"fmt"
+	"errors"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
 
 	"github.com/bwmarrin/discordgo"
@@ -30,36 +31,49 @@ var ConfigCommand = &discordgo.ApplicationCommand{
 			Description: "Select a channels category",
 			Required:    false,
 		},
+		{
+			Type:        discordgo.ApplicationCommandOptionChannel,
+			Name:        "admin_channel",
+			Description: "Select a channel for reports that need an admin",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionChannel,
+			Name:        "announcement_channel",
+			Description: "Select a channel to announce the podium when an event ends",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionRole,
+			Name:        "announcement_role",
+			Description: "Select a role to mention in the podium announcement",
+			Required:    false,
+		},
 	},
 }
 
-func HandleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Defer the response immediately
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		utils.LogError("Error deferring response", err)
-		return
-	}
+func HandleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
 
-	if !utils.IsAdmin(i) {
-		utils.EditResponseError(s, i, fmt.Errorf("you don't have the required permissions"))
-		return
+	// Optional channels are left out of the options when they aren't picked
+	ids := map[string]string{}
+	for _, option := range i.ApplicationCommandData().Options {
+		ids[option.Name] = option.Value.(string)
 	}
 
-	rankingChannelID := i.ApplicationCommandData().Options[0].ChannelValue(s)
-	hiscoreChannelID := i.ApplicationCommandData().Options[1].ChannelValue(s)
-	categoryChannelID := i.ApplicationCommandData().Options[2].ChannelValue(s)
-
-	err = data.UpdateConfig(i.GuildID, rankingChannelID.ID, hiscoreChannelID.ID, categoryChannelID.ID)
+	err := data.UpdateConfig(i.GuildID, data.ChannelSettings{
+		RankingChannelID:      ids["overall_ranking_channel"],
+		HiscoreChannelID:      ids["botm_ranking_channel"],
+		CategoryChannelID:     ids["category_channel"],
+		AdminChannelID:        ids["admin_channel"],
+		AnnouncementChannelID: ids["announcement_channel"],
+		AnnouncementRoleID:    ids["announcement_role"],
+	})
 	if err != nil {
-		utils.EditResponseError(s, i, fmt.Errorf("something went wrong while trying to update the config"))
-		return
+		return errors.New(p.Sprintf("something went wrong while trying to update the config"))
 	}
 
-	utils.EditResponseMessage(s, i, "Config saved!")
+	utils.EditResponseMessage(s, i, p.Sprintf("Config saved!"))
+
+	return nil
 }
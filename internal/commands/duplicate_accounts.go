@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var (
+	// reportedDuplicates holds the duplicates last posted per guild, so the
+	// hourly check only speaks up when something changed
+	reportedDuplicates   = make(map[string]string)
+	reportedDuplicatesMu sync.Mutex
+)
+
+// reportDuplicateAccounts posts the accounts that count for more than one
+// member to the admin channel, if one is configured.
+func reportDuplicateAccounts(s *discordgo.Session, guildID string) {
+	duplicates, err := data.FindDuplicateAccounts(guildID)
+	if err != nil {
+		utils.LogError("Error looking for duplicate accounts", err)
+		return
+	}
+
+	p := i18n.ForGuild(guildID)
+
+	description := ""
+	for _, duplicate := range duplicates {
+		owners := make([]string, len(duplicate.OwnerIds))
+		for i, ownerId := range duplicate.OwnerIds {
+			owners[i] = fmt.Sprintf("<@%s>", ownerId)
+		}
+		description += fmt.Sprintf("• **%s** (%s)\n", duplicate.AccountName, strings.Join(owners, ", "))
+	}
+
+	reportedDuplicatesMu.Lock()
+	unchanged := reportedDuplicates[guildID] == description
+	reportedDuplicates[guildID] = description
+	reportedDuplicatesMu.Unlock()
+	if unchanged || description == "" {
+		return
+	}
+
+	config, err := data.GetBotConfig(guildID)
+	if err != nil || config.AdminChannelID == "" {
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: p.Sprintf("⚠️ Accounts tracked by more than one member"),
+		Color: 0xFFA500,
+		Description: p.Sprintf(
+			"These accounts count for every member tracking them. Decide who they belong to and "+
+				"remove them from the others with `/admin untrack`:\n\n%s",
+			description,
+		),
+	}
+
+	_, err = s.ChannelMessageSendEmbed(config.AdminChannelID, embed)
+	if err != nil {
+		utils.LogError("Error sending duplicate account report", err)
+	}
+}
@@ -1,11 +1,15 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
 )
 
 var EndActivityCommand = &discordgo.ApplicationCommand{
@@ -15,48 +19,83 @@ var EndActivityCommand = &discordgo.ApplicationCommand{
 		{
 			Type:        discordgo.ApplicationCommandOptionString,
 			Name:        "password",
-			Description: "provide the activity password",
-			Required:    true,
+			Description: "provide the activity password, organizers can leave it empty",
 		},
 	},
 }
 
-func HandleEndActivityCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Acknowledge the interaction immediately to prevent timeout
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	})
-	if err != nil {
-		fmt.Printf("Error acknowledging interaction: %v\n", err)
-		return
+func HandleEndActivityCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	// Organizers don't need the password
+	if !data.IsOrganizer(i.GuildID, i.Member.Roles) {
+		password := ""
+		if option, ok := optionsByName(i.ApplicationCommandData().Options)["password"]; ok {
+			password = option.StringValue()
+		}
+
+		err := data.CheckCompetitionPassword(i.GuildID, password)
+		if errors.Is(err, data.ErrIncorrectPassword) {
+			return errors.New(p.Sprintf("that's not the password of this event"))
+		}
+		if err != nil {
+			utils.LogError("Error checking event password", err)
+			return errors.New(p.Sprintf("something went wrong while trying to end the event"))
+		}
 	}
 
-	if !utils.IsAdmin(i) {
-		// Send an error message after deferring
-		utils.EditResponseMessage(s, i, "❌ You do not have the required permissions to use this command.")
-		return
+	// End the competition
+	result, report, err := data.EndCompetition(i.GuildID)
+	var pending *data.PendingReviewsError
+	var schemaErr *data.SchemaError
+	if errors.Is(err, data.ErrNoEventRunning) {
+		return errors.New(p.Sprintf("there is no event running"))
+	}
+	if err != nil && !errors.As(err, &pending) && !errors.As(err, &schemaErr) {
+		utils.LogError("Error ending competition", err)
+		return errors.New(p.Sprintf("something went wrong while trying to end the event"))
 	}
 
-	password := i.ApplicationCommandData().Options[0].StringValue()
+	// The last update ran either way, the admins need to hear what it found
+	notifyStaleAccounts(s, i.GuildID, report.NewlyStale)
+	postKCReviews(s, i.GuildID, report.NewReviews)
+	alertSchemaProblems(s, i.GuildID, report)
 
-	// End the competition
-	err = data.EndCompetition(i.GuildID, password)
-	if err != nil {
-		// Edit the response with an error message
-		utils.EditResponseMessage(s, i, "❌ Something went wrong while trying to end the event.")
-		return
+	if schemaErr != nil {
+		return errors.New(p.Sprintf("the hiscores no longer list *%s*, so the final KC can't be trusted. The event can end once the activity catalog is fixed", strings.Join(schemaErr.Missing, ", ")))
 	}
+	if pending != nil {
+		return errors.New(pendingReviewsMessage(p, pending.Reviews))
+	}
+
+	postPodiumAnnouncement(s, i.GuildID, result)
 
 	// Update the ranking message
 	err = updateRankingMessage(s, i.GuildID)
 	if err != nil {
-		// Edit the response with an error message
-		utils.EditResponseMessage(s, i, "❌ Something went wrong while updating the ranking message.")
-		return
+		utils.LogError("Error updating ranking message", err)
+		return errors.New(p.Sprintf("something went wrong while updating the ranking message"))
 	}
 
 	// Edit the response to indicate success
-	utils.EditResponseMessage(s, i, "✅ The event has ended, and the rankings have been updated!")
+	if report.Unavailable {
+		utils.EditResponseMessage(s, i, p.Sprintf("✅ The event has ended on the last known KC because the hiscores are unavailable, and the rankings have been updated!"))
+		return nil
+	}
+	utils.EditResponseMessage(s, i, p.Sprintf("✅ The event has ended, and the rankings have been updated!"))
+
+	return nil
+}
+
+// pendingReviewsMessage explains that the event can't end yet and lists the
+// KC changes the admins still have to look at.
+func pendingReviewsMessage(p *message.Printer, reviews []data.KCReview) string {
+	var lines []string
+	for _, review := range reviews {
+		lines = append(lines, p.Sprintf("• **%s** (<@%s>): `%d` → `%d` (%+d)", review.AccountName, review.DiscordId, review.PreviousAmount, review.ReportedAmount, review.Change()))
+	}
+
+	return p.Sprintf("the event can't end while KC changes are waiting for review, approve or reject them with `/kc-reviews resolve` first:\n%s", strings.Join(lines, "\n"))
 }
 
 func updateRankingMessage(s *discordgo.Session, guildID string) error {
@@ -70,15 +109,19 @@ func updateRankingMessage(s *discordgo.Session, guildID string) error {
 		return fmt.Errorf("error fetching participants: %w", err)
 	}
 
-	embed := &discordgo.MessageEmbed{
-		Title:       "Competition Ranking\n",
-		Color:       0x999999,
-		Description: "",
+	p := i18n.ForGuild(guildID)
+
+	embed := discordgo.MessageEmbed{
+		Title: p.Sprintf("Competition Ranking") + "\n",
+		Color: 0x999999,
 	}
 
+	// Every rank is a line of its own so it never gets split over two pages
+	header := ""
+	var blocks []string
+
 	if len(participants) == 0 {
-		embed.Description = "🚨 Participants don't have any points yet!"
-		return nil
+		header = p.Sprintf("🚨 Participants don't have any points yet!")
 	}
 
 	rank := 0
@@ -90,8 +133,9 @@ func updateRankingMessage(s *discordgo.Session, guildID string) error {
 		}
 
 		var rankEmoji string
+		line := ""
 		if participant.Points != previousPoints {
-			embed.Description += "\n"
+			line = "\n"
 			rank = i + 1
 			switch rank {
 			case 1:
@@ -103,31 +147,23 @@ func updateRankingMessage(s *discordgo.Session, guildID string) error {
 			rankEmoji = "     "
 		}
 
-		// Add the participant's rank, username, and points to the embed description
-		embed.Description += fmt.Sprintf("%s  **<@%s>**  -  _%d pts_\n", rankEmoji, participant.DiscordId, participant.Points)
+		// Add the participant's rank, username, and points to the page
+		line += p.Sprintf("%s  **<@%s>**  -  _%d pts_\n", rankEmoji, participant.DiscordId, participant.Points)
+		blocks = append(blocks, line)
 
 		// Update tracking variables
 		previousPoints = participant.Points
 	}
 
-	// Send or edit the ranking message
-	if config.RankingMessageID != "" {
-		_, err := s.ChannelMessageEditEmbed(config.RankingChannelID, config.RankingMessageID, embed)
-		if err != nil {
-			newMessage, err := s.ChannelMessageSendEmbed(config.RankingChannelID, embed)
-			if err != nil {
-				return fmt.Errorf("error sending new ranking message: %w", err)
-			}
-
-			data.UpdateRankingMessageID(guildID, newMessage.ID)
-		}
-	} else {
-		newMessage, err := s.ChannelMessageSendEmbed(config.RankingChannelID, embed)
-		if err != nil {
-			return fmt.Errorf("error sending ranking message: %w", err)
-		}
-
-		data.UpdateRankingMessageID(guildID, newMessage.ID)
+	// Send or edit the ranking messages
+	embeds := pagedEmbeds(embed, paginate(header, blocks, ""))
+	img := rankingImage(s, guildID, participants)
+	messageIDs, err := syncPagedMessages(s, config.RankingChannelID, config.RankingMessageIDs, embeds, img, nil)
+	if updateErr := data.UpdateRankingMessageIDs(guildID, messageIDs); updateErr != nil {
+		utils.LogError("Error saving ranking message IDs", updateErr)
+	}
+	if err != nil {
+		return fmt.Errorf("error sending ranking messages: %w", err)
 	}
 
 	return nil
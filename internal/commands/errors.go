@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/service"
+	"misclicked-events/internal/utils"
+
+	"golang.org/x/text/message"
+)
+
+// errorReason explains in the guild's language why a data call failed. The
+// data layer's errors are English and meant for the logs, anything members
+// aren't expected to run into is logged and gets a generic reason.
+func errorReason(p *message.Printer, err error) string {
+	var owned *data.AccountOwnedError
+
+	switch {
+	case errors.As(err, &owned):
+		return p.Sprintf("%s is already tracked by <@%s>", owned.AccountName, owned.OwnerId)
+	case errors.Is(err, data.ErrUnknownAccount), errors.Is(err, service.ErrPlayerNotFound):
+		return p.Sprintf("there is no OSRS account with this name on the hiscores")
+	case errors.Is(err, service.ErrHiscoresUnavailable):
+		return p.Sprintf("the OSRS hiscores are unavailable, try again later")
+	case errors.Is(err, data.ErrAccountAlreadyTracked):
+		return p.Sprintf("the account is already being tracked")
+	case errors.Is(err, data.ErrNoTrackedAccounts):
+		return p.Sprintf("no accounts are being tracked for this member")
+	case errors.Is(err, data.ErrAccountNotFound):
+		return p.Sprintf("no tracked account has this name")
+	case errors.Is(err, data.ErrSameAccountOwner):
+		return p.Sprintf("the account already belongs to this member")
+	case errors.Is(err, data.ErrAccountNotTracked):
+		return p.Sprintf("nobody is tracking this account anymore, try `/track` again")
+	case errors.Is(err, data.ErrClaimHandled):
+		return p.Sprintf("this claim has already been handled")
+	case errors.Is(err, data.ErrReviewHandled):
+		return p.Sprintf("this review has already been handled")
+	case errors.Is(err, data.ErrNoEventRunning):
+		return p.Sprintf("there is no event running")
+	default:
+		utils.LogError("Unexpected error", err)
+		return p.Sprintf("something went wrong, try again later")
+	}
+}
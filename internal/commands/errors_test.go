@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"testing"
+)
+
+func TestErrorReasonTranslatesDataErrors(t *testing.T) {
+	nl := i18n.Printer("nl")
+	en := i18n.Printer("en")
+
+	for _, err := range []error{
+		data.ErrAccountNotFound,
+		fmt.Errorf("%w: Zezima", data.ErrAccountAlreadyTracked),
+		data.ErrNoTrackedAccounts,
+		&data.AccountOwnedError{AccountName: "Zezima", OwnerId: "1"},
+	} {
+		if got := errorReason(nl, err); got == errorReason(en, err) {
+			t.Errorf("errorReason(%v) = %q in both languages", err, got)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// currentEventValue picks the running event in /export, past events are
+// picked by their index in the history.
+const currentEventValue = "current"
+
+var ExportCommand = &discordgo.ApplicationCommand{
+	Name:        "export",
+	Description: "Download the full results of an event as CSV and JSON",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "event",
+			Description:  "The event to export, the current one if left empty",
+			Required:     false,
+			Autocomplete: true,
+		},
+	},
+}
+
+func HandleExportCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	event := currentEventValue
+	if option, ok := optionsByName(i.ApplicationCommandData().Options)["event"]; ok {
+		event = option.StringValue()
+	}
+
+	var export data.EventExport
+	var err error
+	if event == currentEventValue {
+		export, err = data.ExportCurrentEvent(i.GuildID)
+		if err != nil {
+			return errors.New(p.Sprintf("there is no event running, pick a past event to export"))
+		}
+	} else {
+		index, convErr := strconv.Atoi(event)
+		export, err = data.ExportPastEvent(i.GuildID, index)
+		if convErr != nil || err != nil {
+			return errors.New(p.Sprintf("pick an event from the list to export"))
+		}
+	}
+
+	// Spreadsheets want names, not just Discord IDs
+	for j := range export.Participants {
+		export.Participants[j].Name = DisplayName(s, i.GuildID, export.Participants[j].DiscordId)
+	}
+	for j := range export.Ranking {
+		export.Ranking[j].Name = DisplayName(s, i.GuildID, export.Ranking[j].DiscordId)
+	}
+
+	files, err := exportFiles(export)
+	if err != nil {
+		utils.LogError("Error building export", err)
+		return errors.New(p.Sprintf("something went wrong while building the export"))
+	}
+
+	content := p.Sprintf("📦 Results of the %s event.", export.Activity)
+	if !export.StartedAt.IsZero() {
+		content = p.Sprintf("📦 Results of the %s event that started on %s.", export.Activity, export.StartedAt.Format("Jan 02, 2006"))
+	}
+	if export.Ongoing {
+		content += "\n" + p.Sprintf("_The event is still running, the points are what the ranks would earn if it ended now._")
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content: &content,
+		Files:   files,
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+
+	return nil
+}
+
+// exportFiles writes the export as a CSV of the event with a row per account,
+// a CSV of the overall ranking and a JSON file with both.
+func exportFiles(export data.EventExport) ([]*discordgo.File, error) {
+	name := fmt.Sprintf("%s-%s", strings.ToLower(strings.ReplaceAll(export.Activity, " ", "-")), export.StartedAt.Format("2006-01-02"))
+
+	var event bytes.Buffer
+	writer := csv.NewWriter(&event)
+	writer.Write([]string{"rank", "discord_id", "name", "total_kc", "points", "account", "start_kc", "current_kc", "kc", "unverified"})
+	for _, participant := range export.Participants {
+		row := []string{
+			optionalNumber(participant.Rank),
+			participant.DiscordId,
+			csvText(participant.Name),
+			strconv.Itoa(participant.TotalKC),
+			strconv.Itoa(participant.Points),
+		}
+		if len(participant.Accounts) == 0 {
+			writer.Write(append(row, "", "", "", "", ""))
+		}
+		for _, account := range participant.Accounts {
+			writer.Write(append(slices.Clone(row),
+				csvText(account.Name),
+				optionalKC(account.StartKC),
+				optionalKC(account.CurrentKC),
+				strconv.Itoa(account.KC),
+				strconv.FormatBool(account.Unverified),
+			))
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write event CSV: %w", err)
+	}
+
+	var ranking bytes.Buffer
+	writer = csv.NewWriter(&ranking)
+	writer.Write([]string{"rank", "discord_id", "name", "points"})
+	for _, row := range export.Ranking {
+		writer.Write([]string{strconv.Itoa(row.Rank), row.DiscordId, csvText(row.Name), strconv.Itoa(row.Points)})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write ranking CSV: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal export: %w", err)
+	}
+
+	return []*discordgo.File{
+		{Name: name + ".csv", ContentType: "text/csv", Reader: &event},
+		{Name: name + "-ranking.csv", ContentType: "text/csv", Reader: &ranking},
+		{Name: name + ".json", ContentType: "application/json", Reader: bytes.NewReader(jsonData)},
+	}, nil
+}
+
+// csvText keeps spreadsheets from running a name as a formula: members pick
+// their own display names, and one starting with = or @ would be evaluated.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// optionalNumber leaves the cell empty for participants below the threshold.
+func optionalNumber(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+func optionalKC(kc *int) string {
+	if kc == nil {
+		return ""
+	}
+	return strconv.Itoa(*kc)
+}
+
+// HandleExportAutocomplete suggests the running event and the past events,
+// the latest first.
+func HandleExportAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	p := i18n.ForGuild(i.GuildID)
+
+	typed := ""
+	for _, option := range i.ApplicationCommandData().Options {
+		if option.Focused {
+			typed = strings.ToLower(option.StringValue())
+		}
+	}
+
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	if activity := checkOngoingEvent(i.GuildID); activity != "" && strings.Contains(strings.ToLower(activity), typed) {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  p.Sprintf("%s (running)", activity),
+			Value: currentEventValue,
+		})
+	}
+
+	// Without a history there is only the running event to suggest
+	history, _ := data.GetCompetitionHistory(i.GuildID)
+	for index := len(history) - 1; index >= 0 && len(choices) < maxAutocompleteChoices; index-- {
+		result := history[index]
+		if !strings.Contains(strings.ToLower(result.Activity), typed) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s (%s - %s)", result.Activity, result.StartedAt.Format("Jan 02"), result.EndedAt.Format("Jan 02, 2006")),
+			Value: strconv.Itoa(index),
+		})
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		utils.LogError("Error sending autocomplete choices", err)
+	}
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"encoding/csv"
+	"misclicked-events/internal/data"
+	"testing"
+)
+
+func TestCSVText(t *testing.T) {
+	tests := map[string]string{
+		"Zezima":            "Zezima",
+		"":                  "",
+		"=HYPERLINK(\"x\")": "'=HYPERLINK(\"x\")",
+		"+1":                "'+1",
+		"-2+3":              "'-2+3",
+		"@SUM(A1)":          "'@SUM(A1)",
+		"\tcmd":             "'\tcmd",
+		"\rcmd":             "'\rcmd",
+		"a=b":               "a=b",
+	}
+	for input, want := range tests {
+		if got := csvText(input); got != want {
+			t.Errorf("csvText(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestExportFilesEscapeNames(t *testing.T) {
+	files, err := exportFiles(data.EventExport{
+		Activity:     "Zulrah",
+		Participants: []data.ExportParticipant{{DiscordId: "1", Name: "=cmd|' /C calc'!A0", Rank: 1}},
+		Ranking:      []data.ExportRanking{{DiscordId: "1", Name: "@evil", Rank: 1, Points: 12}},
+	})
+	if err != nil {
+		t.Fatalf("exportFiles: %v", err)
+	}
+
+	for _, file := range files[:2] {
+		records, err := csv.NewReader(file.Reader).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", file.Name, err)
+		}
+		name := records[1][2]
+		if name[0] != '\'' {
+			t.Errorf("%s has name %q, want it escaped", file.Name, name)
+		}
+	}
+}
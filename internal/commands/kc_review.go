@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// KCReviewButtonPrefix starts the custom ID of the approve/reject buttons.
+const KCReviewButtonPrefix = "kc_review"
+
+var KCReviewsCommand = &discordgo.ApplicationCommand{
+	Name:        "kc-reviews",
+	Description: "Settle KC changes that were held back for review",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show the KC changes waiting for review",
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "resolve",
+			Description: "Approve or reject a KC change",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "review",
+					Description:  "The KC change to settle",
+					Required:     true,
+					Autocomplete: true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "decision",
+					Description: "Whether the change counts",
+					Required:    true,
+					Choices: []*discordgo.ApplicationCommandOptionChoice{
+						{Name: "Approve", Value: "approve"},
+						{Name: "Reject", Value: "reject"},
+					},
+				},
+			},
+		},
+	},
+}
+
+func HandleKCReviewsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionsByName(subcommand.Options)
+
+	switch subcommand.Name {
+	case "list":
+		reviews, err := data.PendingKCReviews(i.GuildID)
+		if err != nil {
+			utils.LogError("Error fetching KC reviews", err)
+			return errors.New(p.Sprintf("something went wrong while fetching the KC reviews"))
+		}
+		if len(reviews) == 0 {
+			utils.EditResponseMessage(s, i, p.Sprintf("No KC changes are waiting for review."))
+			return nil
+		}
+
+		var lines []string
+		for _, review := range reviews {
+			lines = append(lines, p.Sprintf("• **%s** (<@%s>): `%d` → `%d` %s KC (%+d)", review.AccountName, review.DiscordId, review.PreviousAmount, review.ReportedAmount, review.Activity, review.Change()))
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("These KC changes are waiting for review, settle them with `/kc-reviews resolve`:\n%s", strings.Join(lines, "\n")))
+	case "resolve":
+		approve := options["decision"].StringValue() == "approve"
+		review, err := data.ResolveKCReview(i.GuildID, options["review"].StringValue(), approve)
+		if err != nil {
+			return errors.New(errorReason(p, err))
+		}
+		refreshAfterReview(s, i.GuildID, review)
+
+		if approve {
+			utils.EditResponseMessage(s, i, p.Sprintf("✅ Approved the change of **%s** to `%d` %s KC.", review.AccountName, review.ReportedAmount, review.Activity))
+		} else {
+			utils.EditResponseMessage(s, i, p.Sprintf("❌ Rejected the change of **%s** to `%d` %s KC.", review.AccountName, review.ReportedAmount, review.Activity))
+		}
+	}
+
+	return nil
+}
+
+// HandleKCReviewAutocomplete suggests the KC changes waiting for review.
+func HandleKCReviewAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	if utils.IsAdmin(i) {
+		reviews, err := data.PendingKCReviews(i.GuildID)
+		if err != nil {
+			utils.LogError("Error fetching KC reviews", err)
+		}
+		for _, review := range reviews {
+			if len(choices) == maxAutocompleteChoices {
+				break
+			}
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  truncateChoiceName(fmt.Sprintf("%s: %d → %d %s KC", review.AccountName, review.PreviousAmount, review.ReportedAmount, review.Activity)),
+				Value: review.ID,
+			})
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		utils.LogError("Error sending autocomplete choices", err)
+	}
+}
+
+// postKCReviews asks the admins to look at KC changes that were held back.
+func postKCReviews(s *discordgo.Session, guildID string, reviews []data.KCReview) {
+	if len(reviews) == 0 {
+		return
+	}
+
+	config, err := data.GetBotConfig(guildID)
+	if err != nil || config.AdminChannelID == "" {
+		utils.LogError(fmt.Sprintf("%d KC changes are waiting for review but no admin channel is set up", len(reviews)), err)
+		return
+	}
+
+	p := i18n.ForGuild(guildID)
+
+	for _, review := range reviews {
+		embed := &discordgo.MessageEmbed{
+			Title: p.Sprintf("🔎 Suspicious KC change"),
+			Color: 0xFFA500,
+			Description: p.Sprintf(
+				"**%s** (<@%s>) went from `%d` to `%d` %s KC (%+d).\n\n"+
+					"The change isn't on the leaderboard until it's approved. "+
+					"Rejecting it keeps the current KC and only counts gains after this one.",
+				review.AccountName, review.DiscordId, review.PreviousAmount, review.ReportedAmount, review.Activity, review.Change(),
+			),
+			Timestamp: review.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		}
+
+		_, err := s.ChannelMessageSendComplex(config.AdminChannelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							Label:    p.Sprintf("Approve"),
+							Style:    discordgo.SuccessButton,
+							CustomID: fmt.Sprintf("%s:approve:%s", KCReviewButtonPrefix, review.ID),
+						},
+						discordgo.Button{
+							Label:    p.Sprintf("Reject"),
+							Style:    discordgo.DangerButton,
+							CustomID: fmt.Sprintf("%s:reject:%s", KCReviewButtonPrefix, review.ID),
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			utils.LogError("Error sending KC review", err)
+		}
+	}
+}
+
+func HandleKCReviewButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	p := i18n.ForGuild(i.GuildID)
+
+	if !utils.IsAdmin(i) {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("you don't have the required permissions")))
+		return
+	}
+
+	// Custom IDs look like kc_review:<approve|reject>:<review id>
+	parts := strings.Split(i.MessageComponentData().CustomID, ":")
+	if len(parts) != 3 {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("unknown review button")))
+		return
+	}
+	approve := parts[1] == "approve"
+
+	review, err := data.ResolveKCReview(i.GuildID, parts[2], approve)
+	if err != nil {
+		utils.RespondWithError(s, i, errors.New(errorReason(p, err)))
+		return
+	}
+
+	verdict := p.Sprintf("❌ Rejected")
+	if approve {
+		verdict = p.Sprintf("✅ Approved")
+	}
+
+	embeds := i.Message.Embeds
+	if len(embeds) > 0 {
+		embeds[0].Color = 0x999999
+		embeds[0].Footer = &discordgo.MessageEmbedFooter{
+			Text: p.Sprintf("%s by %s", verdict, i.Member.User.Username),
+		}
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     embeds,
+			Components: []discordgo.MessageComponent{},
+		},
+	})
+	if err != nil {
+		utils.LogError("Error updating review message", err)
+	}
+
+	refreshAfterReview(s, i.GuildID, review)
+}
+
+// refreshAfterReview puts a settled KC change on the leaderboard when it's
+// for the running event.
+func refreshAfterReview(s *discordgo.Session, guildID string, review data.KCReview) {
+	if ongoingEvent := checkOngoingEvent(guildID); ongoingEvent == review.Activity {
+		err := UpdateHiscoreMessage(s, guildID)
+		if err != nil {
+			utils.LogError("Error updating hiscore message", err)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var LanguageCommand = &discordgo.ApplicationCommand{
+	Name:        "language",
+	Description: "Pick the language the bot uses in this server",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "language",
+			Description: "The language to use",
+			Required:    true,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "English", Value: "en"},
+				{Name: "Nederlands", Value: "nl"},
+			},
+		},
+	},
+}
+
+func HandleLanguageCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	locale := i18n.Normalize(optionsByName(i.ApplicationCommandData().Options)["language"].StringValue())
+	err := data.UpdateLocale(i.GuildID, locale)
+	if err != nil {
+		utils.LogError("Error saving locale", err)
+		return errors.New(i18n.ForGuild(i.GuildID).Sprintf("set up the channels with `/setup-channels` before picking a language"))
+	}
+
+	// Redraw the boards so they don't wait for the next update to switch
+	if ongoingEvent := checkOngoingEvent(i.GuildID); ongoingEvent != "" {
+		err = UpdateHiscoreMessage(s, i.GuildID)
+	} else {
+		err = updateNoEventMessage(s, i.GuildID)
+	}
+	if err != nil {
+		utils.LogError("Error updating hiscore message", err)
+	}
+	err = updateRankingMessage(s, i.GuildID)
+	if err != nil {
+		utils.LogError("Error updating ranking message", err)
+	}
+
+	utils.EditResponseMessage(s, i, i18n.Printer(locale).Sprintf("The bot now speaks English in this server."))
+
+	return nil
+}
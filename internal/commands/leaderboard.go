@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/constants"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"slices"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/cases"
+)
+
+var LeaderboardCommand = &discordgo.ApplicationCommand{
+	Name:        "leaderboard",
+	Description: "Check the standings without leaving the channel",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "board",
+			Description: "Which standings to show, the current event if left empty",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Current event", Value: "event"},
+				{Name: "Overall ranking", Value: "overall"},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "account",
+			Description: "Only show participants with an OSRS account matching this name",
+			Required:    false,
+			MaxLength:   maxAccountNameLength,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "me",
+			Description: "Jump to your own position",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionInteger,
+			Name:        "page",
+			Description: "The page to show",
+			Required:    false,
+			MinValue:    &minPage,
+		},
+	},
+}
+
+var minPage = 1.0
+
+// maxAccountNameLength is the longest name an OSRS account can have
+const maxAccountNameLength = 12
+
+// leaderboardView describes what a member asked to see.
+type leaderboardView struct {
+	Board   string
+	Account string
+	Me      bool
+	Page    int
+}
+
+func HandleLeaderboardCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	view := leaderboardView{Board: "event", Page: 1}
+	for _, option := range i.ApplicationCommandData().Options {
+		switch option.Name {
+		case "board":
+			view.Board = option.StringValue()
+		case "account":
+			view.Account = option.StringValue()
+		case "me":
+			view.Me = option.BoolValue()
+		case "page":
+			view.Page = int(option.IntValue())
+		}
+	}
+
+	page, err := buildLeaderboardView(i.GuildID, i.Member.User.ID, view)
+	if err != nil {
+		return err
+	}
+
+	// Edit the deferred response with the embed
+	embeds := []*discordgo.MessageEmbed{page.Embed}
+	components := leaderboardPageButtons(i18n.ForGuild(i.GuildID), view, page)
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &embeds,
+		Components: &components,
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+
+	return nil
+}
+
+// leaderboardPage is a single page of a leaderboard view.
+type leaderboardPage struct {
+	Embed *discordgo.MessageEmbed
+	// Page starts at 1
+	Page  int
+	Pages int
+}
+
+// buildLeaderboardView builds a single page of the requested standings for
+// the member with the given ID.
+func buildLeaderboardView(guildID, discordId string, view leaderboardView) (leaderboardPage, error) {
+	var header, trailer string
+	var blocks []string
+	var ownBlock int
+
+	p := i18n.ForGuild(guildID)
+	embed := discordgo.MessageEmbed{Color: 0xffd700}
+
+	switch view.Board {
+	case "overall":
+		participants, err := data.GetParticipantsInOrder(guildID)
+		if err != nil {
+			utils.LogError("Error fetching participants", err)
+			return leaderboardPage{}, errors.New(p.Sprintf("something went wrong while fetching the ranking"))
+		}
+
+		embed.Title = p.Sprintf("👑 Overall Ranking")
+		// Rank everyone before filtering, so the ranks stay the real ones
+		blocks, participants = filterRanked(rankingBlocks(p, participants, discordId), participants, func(p data.Participant) bool {
+			return participantMatchesAccount(p, view.Account)
+		})
+		ownBlock = slices.IndexFunc(participants, func(p data.Participant) bool {
+			return p.DiscordId == discordId
+		})
+	default:
+		activity := data.GetCurrentBoss(guildID)
+		if activity == "" {
+			return leaderboardPage{}, errors.New(p.Sprintf("there is no event running, try the overall ranking instead"))
+		}
+
+		participantKC, err := data.GetParticipantsByActivityKC(guildID)
+		if err != nil {
+			utils.LogError("Error fetching participants", err)
+			return leaderboardPage{}, errors.New(p.Sprintf("something went wrong while fetching the leaderboard"))
+		}
+
+		// Participants below the threshold come last, they are sorted by KC already
+		threshold := constants.Activities[activity].Threshold
+		split := slices.IndexFunc(participantKC, func(p data.ParticipantKC) bool {
+			return p.TotalKC < threshold
+		})
+		if split < 0 {
+			split = len(participantKC)
+		}
+
+		previous, dayAgo, err := data.PreviousStandings(guildID)
+		if err != nil {
+			utils.LogError("Error fetching previous standings", err)
+		}
+
+		// Rank everyone before filtering, so the ranks and their movement
+		// stay the real ones
+		matches := func(p data.ParticipantKC) bool {
+			return participantKCMatchesAccount(p, view.Account)
+		}
+		aboveBlocks, _ := leaderboardBlocks(p, participantKC[:split], discordId, previous, dayAgo)
+		aboveBlocks, above := filterRanked(aboveBlocks, participantKC[:split], matches)
+		belowBlocks, below := filterRanked(belowThresholdBlocks(p, participantKC[split:], discordId), participantKC[split:], matches)
+
+		embed.Title = p.Sprintf("🏆 %s Leaderboard", activity)
+		blocks = aboveBlocks
+		if len(belowBlocks) > 0 {
+			blocks = append(blocks, p.Sprintf("### Below the threshold (%dkc)\n", threshold))
+			blocks = append(blocks, belowBlocks...)
+		}
+
+		isOwn := func(p data.ParticipantKC) bool {
+			return p.DiscordId == discordId
+		}
+		ownBlock = slices.IndexFunc(above, isOwn)
+		if index := slices.IndexFunc(below, isOwn); index >= 0 {
+			ownBlock = len(above) + 1 + index // Skip the below threshold heading
+		}
+	}
+
+	if view.Account != "" {
+		header = p.Sprintf("_Accounts matching_ `%s`\n\n", view.Account)
+	}
+	if len(blocks) == 0 {
+		trailer = p.Sprintf("🚨 Nobody to show here yet!")
+	}
+
+	pages := paginateBlocks(header, blocks, trailer)
+
+	pageIndex := min(max(view.Page, 1), len(pages)) - 1
+	if view.Me {
+		if ownBlock < 0 {
+			return leaderboardPage{}, errors.New(p.Sprintf("you're not on this leaderboard"))
+		}
+		pageIndex = pageOfBlock(pages, ownBlock)
+	}
+
+	embed.Description = pages[pageIndex].Text
+	embed.Footer = &discordgo.MessageEmbedFooter{
+		Text: p.Sprintf("Page %d/%d", pageIndex+1, len(pages)),
+	}
+
+	return leaderboardPage{Embed: &embed, Page: pageIndex + 1, Pages: len(pages)}, nil
+}
+
+// filterRanked keeps the blocks of the items keep accepts, blocks[i] being the
+// block of items[i].
+func filterRanked[T any](blocks []string, items []T, keep func(T) bool) ([]string, []T) {
+	var keptBlocks []string
+	var keptItems []T
+	for i, item := range items {
+		if keep(item) {
+			keptBlocks = append(keptBlocks, blocks[i])
+			keptItems = append(keptItems, item)
+		}
+	}
+	return keptBlocks, keptItems
+}
+
+func participantMatchesAccount(participant data.Participant, account string) bool {
+	if account == "" {
+		return true
+	}
+	for _, linked := range participant.LinkedOSRSAccounts {
+		if accountNameMatches(linked.Name, account) {
+			return true
+		}
+	}
+	return false
+}
+
+func participantKCMatchesAccount(participant data.ParticipantKC, account string) bool {
+	if account == "" {
+		return true
+	}
+	for _, linked := range participant.AccountKCs {
+		if accountNameMatches(linked.AccountName, account) {
+			return true
+		}
+	}
+	return false
+}
+
+func accountNameMatches(name, search string) bool {
+	return strings.Contains(cases.Fold().String(name), cases.Fold().String(search))
+}
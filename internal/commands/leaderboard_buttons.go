@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/service"
+	"misclicked-events/internal/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+// LeaderboardButtonPrefix starts the custom ID of the buttons on leaderboards.
+const LeaderboardButtonPrefix = "leaderboard"
+
+// refreshCooldown keeps members from hammering the hiscores with refreshes
+const refreshCooldown = 10 * time.Minute
+
+var (
+	lastRefreshMu sync.Mutex
+	// lastRefresh holds when each guild's KC was last updated or refreshed
+	lastRefresh = map[string]time.Time{}
+)
+
+// markRefreshed restarts the refresh cooldown of the guild.
+func markRefreshed(guildID string) {
+	lastRefreshMu.Lock()
+	defer lastRefreshMu.Unlock()
+	lastRefresh[guildID] = time.Now()
+}
+
+// claimRefresh starts the refresh cooldown of the guild when it has passed,
+// otherwise it returns how long is left.
+func claimRefresh(guildID string) (time.Duration, bool) {
+	lastRefreshMu.Lock()
+	defer lastRefreshMu.Unlock()
+
+	if remaining := refreshCooldown - time.Since(lastRefresh[guildID]); remaining > 0 {
+		return remaining, false
+	}
+	lastRefresh[guildID] = time.Now()
+	return 0, true
+}
+
+// leaderboardButtons are shown below the live leaderboard. Browsing pages is
+// only offered when the leaderboard doesn't fit in one message.
+func leaderboardButtons(p *message.Printer, browsable bool) []discordgo.MessageComponent {
+	buttons := []discordgo.MessageComponent{
+		discordgo.Button{
+			Label:    p.Sprintf("Refresh now"),
+			Emoji:    &discordgo.ComponentEmoji{Name: "🔄"},
+			Style:    discordgo.SecondaryButton,
+			CustomID: LeaderboardButtonPrefix + ":refresh",
+		},
+		discordgo.Button{
+			Label:    p.Sprintf("Show my position"),
+			Emoji:    &discordgo.ComponentEmoji{Name: "📍"},
+			Style:    discordgo.PrimaryButton,
+			CustomID: LeaderboardButtonPrefix + ":me",
+		},
+	}
+	if browsable {
+		buttons = append(buttons, discordgo.Button{
+			Label:    p.Sprintf("Browse pages"),
+			Emoji:    &discordgo.ComponentEmoji{Name: "📖"},
+			Style:    discordgo.SecondaryButton,
+			CustomID: LeaderboardButtonPrefix + ":browse",
+		})
+	}
+
+	return []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}}
+}
+
+// leaderboardPageButtons lets a member page through their own view of a
+// leaderboard. Views that fit on one page get no buttons.
+func leaderboardPageButtons(p *message.Printer, view leaderboardView, page leaderboardPage) []discordgo.MessageComponent {
+	if page.Pages <= 1 {
+		return []discordgo.MessageComponent{}
+	}
+
+	// Custom IDs look like leaderboard:page:<board>:<page>:<account filter>
+	pageID := func(number int) string {
+		return fmt.Sprintf("%s:page:%s:%d:%s", LeaderboardButtonPrefix, view.Board, number, view.Account)
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    p.Sprintf("Previous page"),
+					Emoji:    &discordgo.ComponentEmoji{Name: "◀️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: pageID(page.Page - 1),
+					Disabled: page.Page <= 1,
+				},
+				discordgo.Button{
+					Label:    p.Sprintf("Next page"),
+					Emoji:    &discordgo.ComponentEmoji{Name: "▶️"},
+					Style:    discordgo.SecondaryButton,
+					CustomID: pageID(page.Page + 1),
+					Disabled: page.Page >= page.Pages,
+				},
+			},
+		},
+	}
+}
+
+func HandleLeaderboardButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	parts := strings.Split(i.MessageComponentData().CustomID, ":")
+	if len(parts) < 2 {
+		utils.RespondWithError(s, i, errors.New(i18n.ForGuild(i.GuildID).Sprintf("unknown leaderboard button")))
+		return
+	}
+
+	switch parts[1] {
+	case "refresh":
+		handleRefreshButton(s, i)
+	case "me":
+		respondWithLeaderboardView(s, i, leaderboardView{Board: "event", Me: true}, false)
+	case "browse":
+		respondWithLeaderboardView(s, i, leaderboardView{Board: "event", Page: 1}, false)
+	case "page":
+		if len(parts) != 5 {
+			utils.RespondWithError(s, i, errors.New(i18n.ForGuild(i.GuildID).Sprintf("unknown leaderboard button")))
+			return
+		}
+		page, _ := strconv.Atoi(parts[3])
+		respondWithLeaderboardView(s, i, leaderboardView{Board: parts[2], Page: page, Account: parts[4]}, true)
+	default:
+		utils.RespondWithError(s, i, errors.New(i18n.ForGuild(i.GuildID).Sprintf("unknown leaderboard button")))
+	}
+}
+
+// respondWithLeaderboardView shows the member a page of the standings, in a
+// new private message or by updating the private message the button is on.
+func respondWithLeaderboardView(s *discordgo.Session, i *discordgo.InteractionCreate, view leaderboardView, update bool) {
+	page, err := buildLeaderboardView(i.GuildID, i.Member.User.ID, view)
+	if err != nil {
+		utils.RespondWithError(s, i, err)
+		return
+	}
+
+	responseType := discordgo.InteractionResponseChannelMessageWithSource
+	if update {
+		responseType = discordgo.InteractionResponseUpdateMessage
+	}
+
+	err = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: responseType,
+		Data: &discordgo.InteractionResponseData{
+			Embeds:     []*discordgo.MessageEmbed{page.Embed},
+			Components: leaderboardPageButtons(i18n.ForGuild(i.GuildID), view, page),
+			Flags:      discordgo.MessageFlagsEphemeral,
+		},
+	})
+	if err != nil {
+		utils.LogError("Error sending leaderboard view", err)
+	}
+}
+
+func handleRefreshButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	p := i18n.ForGuild(i.GuildID)
+
+	if checkOngoingEvent(i.GuildID) == "" {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("there is no event running")))
+		return
+	}
+	if !service.Health().Available {
+		utils.RespondWithError(s, i, errors.New(p.Sprintf("the OSRS hiscores are unavailable right now, try again later")))
+		return
+	}
+
+	remaining, ok := claimRefresh(i.GuildID)
+	if !ok {
+		minutes := int(math.Ceil(remaining.Minutes()))
+		utils.RespondWithPrivateMessage(s, i, "⏳ The leaderboard was updated recently, you can refresh it again in %d minutes.", minutes)
+		return
+	}
+
+	// Updating takes a while, the leaderboard itself shows the result
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		utils.LogError("Error deferring refresh", err)
+		return
+	}
+
+	updateGuild(s, i.GuildID)
+
+	_, err = s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+		Content: p.Sprintf("✅ The leaderboard has been refreshed."),
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		utils.LogError("Error sending refresh confirmation", err)
+	}
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/constants"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/render"
+	"misclicked-events/internal/utils"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// displayNameTTL is how long a looked up name is reused. Without the members
+// intent the state has nobody, and the hourly images would otherwise ask
+// Discord for every participant every time.
+const displayNameTTL = 6 * time.Hour
+
+type cachedDisplayName struct {
+	Name      string
+	FetchedAt time.Time
+}
+
+var (
+	displayNamesMu sync.Mutex
+	// displayNames is keyed by guild ID and Discord ID
+	displayNames = map[[2]string]cachedDisplayName{}
+)
+
+// DisplayName returns the name a member goes by in the guild. Images can't
+// show mentions, so this is what gets drawn instead. Names looked up through
+// the API are cached for displayNameTTL.
+func DisplayName(s *discordgo.Session, guildID, discordId string) string {
+	member, err := s.State.Member(guildID, discordId)
+	if err == nil {
+		return memberName(member, discordId)
+	}
+
+	key := [2]string{guildID, discordId}
+	displayNamesMu.Lock()
+	cached, ok := displayNames[key]
+	displayNamesMu.Unlock()
+	if ok && time.Since(cached.FetchedAt) < displayNameTTL {
+		return cached.Name
+	}
+
+	name := discordId
+	member, err = s.GuildMember(guildID, discordId)
+	if err == nil {
+		name = memberName(member, discordId)
+	} else if !isUnknownMember(err) {
+		// Try again next time, members that left are cached as they won't come back
+		return name
+	}
+
+	displayNamesMu.Lock()
+	displayNames[key] = cachedDisplayName{Name: name, FetchedAt: time.Now()}
+	displayNamesMu.Unlock()
+
+	return name
+}
+
+func isUnknownMember(err error) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+func memberName(member *discordgo.Member, discordId string) string {
+	switch {
+	case member.Nick != "":
+		return member.Nick
+	case member.User != nil && member.User.GlobalName != "":
+		return member.User.GlobalName
+	case member.User != nil:
+		return member.User.Username
+	default:
+		return discordId
+	}
+}
+
+// hiscoreImage draws the KC leaderboard of the current event. It returns nil
+// when drawing fails, the text leaderboard still works without it.
+func hiscoreImage(s *discordgo.Session, guildID, activity string, participantKC []data.ParticipantKC, footer string) *pageImage {
+	p := i18n.ForGuild(guildID)
+	board := render.Leaderboard{
+		Title:    p.Sprintf("Killcount Leaderboard"),
+		Subtitle: activity,
+		Footer:   footer,
+	}
+
+	if thumbnailURL := constants.Activities[activity].BossThumbnail; thumbnailURL != "" {
+		thumbnail, err := render.FetchImage(thumbnailURL)
+		if err != nil {
+			utils.LogError("Error fetching boss thumbnail", err)
+		}
+		board.Thumbnail = thumbnail
+	}
+
+	rank := 0
+	previousKC := -1
+	for i, participant := range participantKC {
+		if participant.TotalKC != previousKC {
+			rank = i + 1
+		}
+		previousKC = participant.TotalKC
+
+		row := render.LeaderboardRow{
+			Rank:    rank,
+			Name:    DisplayName(s, guildID, participant.DiscordId),
+			TotalKC: participant.TotalKC,
+			Points:  data.PointsForRank(rank),
+		}
+		for _, account := range participant.AccountKCs {
+			row.Accounts = append(row.Accounts, render.AccountRow{
+				Name:  account.AccountName,
+				KC:    account.TotalKC,
+				Stale: account.Stale,
+			})
+		}
+		board.Rows = append(board.Rows, row)
+	}
+
+	png, err := render.RenderLeaderboard(p, board)
+	if err != nil {
+		utils.LogError("Error rendering leaderboard image", err)
+		return nil
+	}
+
+	return &pageImage{Name: "leaderboard.png", Data: png}
+}
+
+// rankingImage draws the overall ranking, or returns nil when drawing fails.
+func rankingImage(s *discordgo.Session, guildID string, participants []data.Participant) *pageImage {
+	var rows []render.RankingRow
+
+	rank := 0
+	previousPoints := -1
+	for i, participant := range participants {
+		if participant.Points == 0 {
+			continue
+		}
+		if participant.Points != previousPoints {
+			rank = i + 1
+		}
+		previousPoints = participant.Points
+
+		rows = append(rows, render.RankingRow{
+			Rank:   rank,
+			Name:   DisplayName(s, guildID, participant.DiscordId),
+			Points: participant.Points,
+		})
+	}
+
+	p := i18n.ForGuild(guildID)
+	png, err := render.RenderRanking(p, p.Sprintf("Competition Ranking"), rows)
+	if err != nil {
+		utils.LogError("Error rendering ranking image", err)
+		return nil
+	}
+
+	return &pageImage{Name: "ranking.png", Data: png}
+}
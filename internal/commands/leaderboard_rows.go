@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"misclicked-events/internal/data"
+
+	"golang.org/x/text/message"
+)
+
+// leaderboardBlocks builds a block of text per participant: their rank, total
+// KC and the KC of each account. Equal KC shares a rank. The participant
+// matching highlightID, if any, is pointed out. When earlier standings are
+// given, each row shows its rank movement and KC gained since then.
+func leaderboardBlocks(p *message.Printer, participantKC []data.ParticipantKC, highlightID string, previous, dayAgo *data.StandingsSnapshot) ([]string, bool) {
+	// Initialize rank tracking variables
+	rank := 0
+	previousKC := -1 // Set to a value that cannot match any valid TotalKC
+	currentRank := 1 // The rank being assigned to participants
+	hasStale := false
+	var blocks []string
+
+	for _, participant := range participantKC {
+		// Check if the current participant's TotalKC is different from the previous one
+		if participant.TotalKC != previousKC {
+			currentRank = rank + 1 // Update the current rank
+		}
+
+		// Assign appropriate emoji for ranks
+		var rankEmoji string
+		switch currentRank {
+		case 1:
+			rankEmoji = "🥇" // Gold Medal
+		case 2:
+			rankEmoji = "🥈" // Silver Medal
+		case 3:
+			rankEmoji = "🥉" // Bronze Medal
+		default:
+			rankEmoji = fmt.Sprintf("%d.", currentRank) // Numeric ranking for 4th and beyond
+		}
+
+		// Build account-specific details
+		accountDetails := ""
+		for _, account := range participant.AccountKCs {
+			staleMarker := ""
+			if account.Stale {
+				staleMarker = p.Sprintf(" ⚠️ _stale_")
+				hasStale = true
+			}
+			accountDetails += p.Sprintf("\u00A0\u00A0\u00A0\u00A0 ┗ *%s: %d*%s\n", account.AccountName, account.TotalKC, staleMarker)
+		}
+
+		// Point out the member who asked for the leaderboard
+		mention := fmt.Sprintf("**<@%s>**", participant.DiscordId)
+		if participant.DiscordId == highlightID {
+			mention = fmt.Sprintf("👉 __**<@%s>**__", participant.DiscordId)
+		}
+
+		// Add the rank, mention, total KC, and account details to the description
+		blocks = append(blocks, p.Sprintf(
+			"%s %s%s - **Total KC:** `%d`%s\n%s\n",
+			rankEmoji, mention, rankMovement(participant.DiscordId, currentRank, previous),
+			participant.TotalKC, kcGains(p, participant, previous, dayAgo), accountDetails,
+		))
+
+		// Update rank and previousKC
+		rank++
+		previousKC = participant.TotalKC
+	}
+
+	return blocks, hasStale
+}
+
+// belowThresholdBlocks builds a line per participant that hasn't reached the
+// threshold yet. They have no rank, so they're listed by KC only.
+func belowThresholdBlocks(p *message.Printer, participantKC []data.ParticipantKC, highlightID string) []string {
+	var blocks []string
+	for _, participant := range participantKC {
+		mention := fmt.Sprintf("<@%s>", participant.DiscordId)
+		if participant.DiscordId == highlightID {
+			mention = fmt.Sprintf("👉 __**<@%s>**__", participant.DiscordId)
+		}
+		blocks = append(blocks, p.Sprintf("▫️ %s - `%d` KC\n", mention, participant.TotalKC))
+	}
+	return blocks
+}
+
+// rankingBlocks builds a line per participant of the overall ranking. Equal
+// points share a rank.
+func rankingBlocks(p *message.Printer, participants []data.Participant, highlightID string) []string {
+	var blocks []string
+
+	rank := 0
+	previousPoints := -1
+	for i, participant := range participants {
+		if participant.Points != previousPoints {
+			rank = i + 1
+		}
+		previousPoints = participant.Points
+
+		rankEmoji := fmt.Sprintf("%d.", rank)
+		if rank == 1 && participant.Points > 0 {
+			rankEmoji = "👑"
+		}
+
+		mention := fmt.Sprintf("**<@%s>**", participant.DiscordId)
+		if participant.DiscordId == highlightID {
+			mention = fmt.Sprintf("👉 __**<@%s>**__", participant.DiscordId)
+		}
+
+		blocks = append(blocks, p.Sprintf("%s %s - _%d pts_\n", rankEmoji, mention, participant.Points))
+	}
+
+	return blocks
+}
+
+// rankMovement shows how a participant's rank changed since the previous
+// standings, or nothing when it didn't.
+func rankMovement(discordId string, rank int, previous *data.StandingsSnapshot) string {
+	if previous == nil {
+		return ""
+	}
+
+	entry, ok := previous.Entry(discordId)
+	switch {
+	case !ok || entry.Rank == 0:
+		return " 🆕"
+	case entry.Rank > rank:
+		return fmt.Sprintf(" 🔺%d", entry.Rank-rank)
+	case entry.Rank < rank:
+		return fmt.Sprintf(" 🔻%d", rank-entry.Rank)
+	default:
+		return ""
+	}
+}
+
+// kcGains shows the KC gained since the previous update and over the last day.
+func kcGains(p *message.Printer, participant data.ParticipantKC, previous, dayAgo *data.StandingsSnapshot) string {
+	if previous == nil || dayAgo == nil {
+		return ""
+	}
+
+	// Participants missing from a snapshot had no KC yet
+	previousEntry, _ := previous.Entry(participant.DiscordId)
+	dayAgoEntry, _ := dayAgo.Entry(participant.DiscordId)
+
+	return p.Sprintf(
+		" _(%+d, %+d in 24h)_",
+		participant.TotalKC-previousEntry.TotalKC,
+		participant.TotalKC-dayAgoEntry.TotalKC,
+	)
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"misclicked-events/internal/data"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestFilterRankedKeepsRanks(t *testing.T) {
+	p := message.NewPrinter(language.English)
+	participantKC := []data.ParticipantKC{
+		{DiscordId: "1", TotalKC: 50, AccountKCs: []data.AccountKC{{AccountName: "Alpha", TotalKC: 50}}},
+		{DiscordId: "2", TotalKC: 40, AccountKCs: []data.AccountKC{{AccountName: "Bravo", TotalKC: 40}}},
+		{DiscordId: "3", TotalKC: 40, AccountKCs: []data.AccountKC{{AccountName: "Charlie", TotalKC: 40}}},
+		{DiscordId: "4", TotalKC: 30, AccountKCs: []data.AccountKC{{AccountName: "Delta", TotalKC: 30}}},
+	}
+	previous := &data.StandingsSnapshot{Participants: []data.SnapshotEntry{
+		{DiscordId: "4", Rank: 1, TotalKC: 20},
+	}}
+
+	blocks, _ := leaderboardBlocks(p, participantKC, "", previous, previous)
+	blocks, kept := filterRanked(blocks, participantKC, func(p data.ParticipantKC) bool {
+		return participantKCMatchesAccount(p, "delta")
+	})
+
+	if len(blocks) != 1 || kept[0].DiscordId != "4" {
+		t.Fatalf("kept %+v, want only Delta", kept)
+	}
+	if !strings.HasPrefix(blocks[0], "4. ") || !strings.Contains(blocks[0], "🔻3") {
+		t.Errorf("block = %q, want rank 4 down 3 places", blocks[0])
+	}
+
+	dropped := &data.StandingsSnapshot{Participants: []data.SnapshotEntry{{DiscordId: "4", TotalKC: 32}}}
+	if gains := kcGains(p, participantKC[3], dropped, previous); gains != " _(-2, +10 in 24h)_" {
+		t.Errorf("kcGains = %q, want a drop of 2 and a gain of 10", gains)
+	}
+
+	participants := []data.Participant{
+		{DiscordId: "1", Points: 12},
+		{DiscordId: "2", Points: 9, LinkedOSRSAccounts: map[string]data.OSRSAccount{"bravo": {Name: "Bravo"}}},
+	}
+	rankingBlocks, _ := filterRanked(rankingBlocks(p, participants, ""), participants, func(p data.Participant) bool {
+		return participantMatchesAccount(p, "bravo")
+	})
+	if len(rankingBlocks) != 1 || !strings.HasPrefix(rankingBlocks[0], "2. ") {
+		t.Errorf("ranking blocks = %q, want Bravo at rank 2", rankingBlocks)
+	}
+}
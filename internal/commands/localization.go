@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"maps"
+	"misclicked-events/internal/i18n"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// localizeCommand fills in the translated names and descriptions of a command,
+// its options and their choices, so Discord shows them in the member's language.
+func localizeCommand(command *discordgo.ApplicationCommand) {
+	command.NameLocalizations = localizationsPtr(discordLocalizations(command.Name))
+	command.DescriptionLocalizations = localizationsPtr(discordLocalizations(command.Description))
+	localizeOptions(command.Options)
+}
+
+func localizeOptions(options []*discordgo.ApplicationCommandOption) {
+	for _, option := range options {
+		option.NameLocalizations = discordLocalizations(option.Name)
+		option.DescriptionLocalizations = discordLocalizations(option.Description)
+		for _, choice := range option.Choices {
+			choice.NameLocalizations = discordLocalizations(choice.Name)
+		}
+		localizeOptions(option.Options)
+	}
+}
+
+// discordLocalizations returns the translations of a text keyed by Discord
+// locale, or nil when there are none.
+func discordLocalizations(text string) map[discordgo.Locale]string {
+	var localizations map[discordgo.Locale]string
+	for locale, translation := range i18n.Translations(text) {
+		if localizations == nil {
+			localizations = map[discordgo.Locale]string{}
+		}
+		localizations[discordgo.Locale(locale)] = translation
+	}
+	return localizations
+}
+
+func localizationsPtr(localizations map[discordgo.Locale]string) *map[discordgo.Locale]string {
+	if localizations == nil {
+		return nil
+	}
+	return &localizations
+}
+
+func localizationPtrsAreEqual(new, existing *map[discordgo.Locale]string) bool {
+	var newMap, existingMap map[discordgo.Locale]string
+	if new != nil {
+		newMap = *new
+	}
+	if existing != nil {
+		existingMap = *existing
+	}
+	return maps.Equal(newMap, existingMap)
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/constants"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+var NotificationsCommand = &discordgo.ApplicationCommand{
+	Name:        "notifications",
+	Description: "Pick which updates about your standing you get",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "overtake",
+			Description: "Let me know when someone overtakes me",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "threshold",
+			Description: "Let me know when I reach the threshold",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "milestone",
+			Description: "Let me know when I reach a round KC number",
+			Required:    false,
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionString,
+			Name:        "delivery",
+			Description: "How to let you know",
+			Required:    false,
+			Choices: []*discordgo.ApplicationCommandOptionChoice{
+				{Name: "Direct message", Value: "dm"},
+				{Name: "Ping in the announcement channel", Value: "channel"},
+			},
+		},
+	},
+}
+
+func HandleNotificationsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+	memberID := i.Member.User.ID
+
+	settings, err := data.GetNotificationSettingsFor(i.GuildID, memberID)
+	if err != nil {
+		utils.LogError("Error fetching notification settings", err)
+		return errors.New(p.Sprintf("something went wrong while fetching your notification settings"))
+	}
+
+	// Without options the current settings are shown as they are
+	options := i.ApplicationCommandData().Options
+	for _, option := range options {
+		switch option.Name {
+		case "overtake":
+			settings.Overtake = option.BoolValue()
+		case "threshold":
+			settings.Threshold = option.BoolValue()
+		case "milestone":
+			settings.Milestone = option.BoolValue()
+		case "delivery":
+			settings.InChannel = option.StringValue() == "channel"
+		}
+	}
+
+	if len(options) > 0 {
+		err = data.UpdateNotificationSettings(i.GuildID, memberID, settings)
+		if err != nil {
+			utils.LogError("Error saving notification settings", err)
+			return errors.New(p.Sprintf("something went wrong while saving your notification settings"))
+		}
+	}
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{notificationSettingsEmbed(p, settings)},
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+
+	return nil
+}
+
+func notificationSettingsEmbed(p *message.Printer, settings data.NotificationSettings) *discordgo.MessageEmbed {
+	state := func(enabled bool) string {
+		if enabled {
+			return p.Sprintf("✅ On")
+		}
+		return p.Sprintf("❌ Off")
+	}
+
+	delivery := p.Sprintf("Direct message")
+	if settings.InChannel {
+		delivery = p.Sprintf("Ping in the announcement channel")
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       p.Sprintf("🔔 Your notifications"),
+		Color:       0x3498db,
+		Description: p.Sprintf("Change them with the options of `/notifications`."),
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: p.Sprintf("Overtaken"), Value: state(settings.Overtake), Inline: true},
+			{Name: p.Sprintf("Threshold reached"), Value: state(settings.Threshold), Inline: true},
+			{Name: p.Sprintf("KC milestones"), Value: state(settings.Milestone), Inline: true},
+			{Name: p.Sprintf("Delivery"), Value: delivery, Inline: true},
+		},
+	}
+}
+
+// notifyStandingsChanges tells the members that opted in how their standing
+// changed since the previous update, in one message per member.
+func notifyStandingsChanges(s *discordgo.Session, guildID string) {
+	notifications, err := data.StandingsNotifications(guildID)
+	if err != nil {
+		utils.LogError("Error comparing standings", err)
+		return
+	}
+	if len(notifications) == 0 {
+		return
+	}
+
+	p := i18n.ForGuild(guildID)
+	activity := data.GetCurrentBoss(guildID)
+
+	config, err := data.GetBotConfig(guildID)
+	if err != nil {
+		utils.LogError("Error fetching bot configuration", err)
+		return
+	}
+
+	// The notifications are sorted by member
+	for start := 0; start < len(notifications); {
+		end := start
+		description := ""
+		for end < len(notifications) && notifications[end].DiscordId == notifications[start].DiscordId {
+			description += notificationLine(p, activity, notifications[end]) + "\n"
+			end++
+		}
+		first := notifications[start]
+		start = end
+
+		embed := &discordgo.MessageEmbed{
+			Title:       p.Sprintf("📣 %s event update", activity),
+			Color:       0x3498db,
+			Description: description,
+			Footer: &discordgo.MessageEmbedFooter{
+				Text: p.Sprintf("Change what you hear about with /notifications"),
+			},
+		}
+
+		if first.InChannel && config.AnnouncementChannelID != "" {
+			_, err = s.ChannelMessageSendComplex(config.AnnouncementChannelID, &discordgo.MessageSend{
+				Content: fmt.Sprintf("<@%s>", first.DiscordId),
+				Embeds:  []*discordgo.MessageEmbed{embed},
+				AllowedMentions: &discordgo.MessageAllowedMentions{
+					Users: []string{first.DiscordId},
+				},
+			})
+		} else {
+			// Without an announcement channel a ping has nowhere to go
+			err = sendDirectMessage(s, first.DiscordId, embed)
+		}
+		if err != nil {
+			utils.LogError(fmt.Sprintf("Error sending standings notification to %s", first.DiscordId), err)
+		}
+	}
+}
+
+func notificationLine(p *message.Printer, activity string, notification data.StandingsNotification) string {
+	switch notification.Kind {
+	case data.NotifyOvertaken:
+		return p.Sprintf("🔻 <@%s> overtook you with `%d` KC, you're at `%d` KC.",
+			notification.OtherId, notification.OtherKC, notification.KC)
+	case data.NotifyThreshold:
+		return p.Sprintf("🎯 You reached the threshold of %dkc with `%d` KC, you're on the leaderboard now!",
+			constants.Activities[activity].Threshold, notification.KC)
+	default:
+		return p.Sprintf("🏅 You reached `%d` KC!", notification.Milestone)
+	}
+}
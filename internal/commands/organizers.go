@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var OrganizersCommand = &discordgo.ApplicationCommand{
+	Name:        "organizers",
+	Description: "Manage the roles that start and end events",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Let a role start and end events without the event password",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role of the organizers",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Stop a role from starting and ending events",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionRole,
+					Name:        "role",
+					Description: "The role that no longer organizes events",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show the roles that start and end events",
+		},
+	},
+}
+
+func HandleOrganizersCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionsByName(subcommand.Options)
+
+	switch subcommand.Name {
+	case "add":
+		roleID := options["role"].Value.(string)
+		err := data.AddOrganizerRole(i.GuildID, roleID)
+		if errors.Is(err, data.ErrOrganizerRoleExists) {
+			return errors.New(p.Sprintf("<@&%s> already organizes the events", roleID))
+		}
+		if err != nil {
+			utils.LogError("Error adding organizer role", err)
+			return errors.New(p.Sprintf("set up the channels with `/setup-channels` before adding organizers"))
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("✅ Members with <@&%s> can now start and end events without the event password.", roleID))
+	case "remove":
+		roleID := options["role"].Value.(string)
+		err := data.RemoveOrganizerRole(i.GuildID, roleID)
+		if errors.Is(err, data.ErrOrganizerRoleNotFound) {
+			return errors.New(p.Sprintf("<@&%s> doesn't organize the events", roleID))
+		}
+		if err != nil {
+			utils.LogError("Error removing organizer role", err)
+			return errors.New(p.Sprintf("something went wrong while removing the organizer role"))
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("✅ Members with <@&%s> no longer organize the events.", roleID))
+	case "list":
+		roles := data.GetOrganizerRoles(i.GuildID)
+		if len(roles) == 0 {
+			utils.EditResponseMessage(s, i, p.Sprintf("Only admins run the events, add organizers with `/organizers add`."))
+			return nil
+		}
+
+		list := ""
+		for _, roleID := range roles {
+			list += fmt.Sprintf("• <@&%s>\n", roleID)
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("These roles start and end events:\n%s", list))
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// embedDescriptionLimit stays a little under Discord's 4096 characters so
+// titles and footers never push a message over its 6000 character total.
+const embedDescriptionLimit = 4000
+
+// page is a piece of a paginated description. Blocks holds the number of
+// blocks on this and all previous pages.
+type page struct {
+	Text   string
+	Blocks int
+}
+
+// paginate spreads blocks over as few pages as possible. The header starts the
+// first page and the trailer ends the last one, blocks are never split.
+func paginate(header string, blocks []string, trailer string) []string {
+	pages := paginateBlocks(header, blocks, trailer)
+	texts := make([]string, len(pages))
+	for i, p := range pages {
+		texts[i] = p.Text
+	}
+	return texts
+}
+
+// paginateBlocks is paginate, but keeps track of which blocks ended up on
+// which page.
+func paginateBlocks(header string, blocks []string, trailer string) []page {
+	pages := []page{{Text: header}}
+
+	for _, block := range blocks {
+		current := pages[len(pages)-1]
+		if current.Text != "" && utf8.RuneCountInString(current.Text)+utf8.RuneCountInString(block) > embedDescriptionLimit {
+			pages = append(pages, page{Blocks: current.Blocks})
+		}
+		pages[len(pages)-1].Text += block
+		pages[len(pages)-1].Blocks++
+	}
+
+	last := len(pages) - 1
+	if pages[last].Text != "" && utf8.RuneCountInString(pages[last].Text)+utf8.RuneCountInString(trailer) > embedDescriptionLimit {
+		pages = append(pages, page{Blocks: pages[last].Blocks})
+		last++
+	}
+	pages[last].Text += trailer
+
+	return pages
+}
+
+// pageOfBlock returns the index of the page the block with the given index is on.
+func pageOfBlock(pages []page, block int) int {
+	for i, p := range pages {
+		if block < p.Blocks {
+			return i
+		}
+	}
+	return len(pages) - 1
+}
+
+// pagedEmbeds turns pages into one embed each, based on a template embed. The
+// title gets a page number and only the first page keeps the thumbnail.
+func pagedEmbeds(template discordgo.MessageEmbed, pages []string) []*discordgo.MessageEmbed {
+	embeds := make([]*discordgo.MessageEmbed, 0, len(pages))
+	for i, page := range pages {
+		embed := template
+		embed.Description = page
+		if len(pages) > 1 {
+			embed.Title = fmt.Sprintf("%s (%d/%d)", template.Title, i+1, len(pages))
+		}
+		if i > 0 {
+			embed.Thumbnail = nil
+		}
+		embeds = append(embeds, &embed)
+	}
+	return embeds
+}
+
+// pageImage is an image shown in the first page of a paged message.
+type pageImage struct {
+	Name string
+	Data []byte
+}
+
+func (img *pageImage) file() *discordgo.File {
+	return &discordgo.File{Name: img.Name, ContentType: "image/png", Reader: bytes.NewReader(img.Data)}
+}
+
+// syncPagedMessages makes the channel show embeds as one message each, in
+// order. Existing messages are edited in place, missing ones are sent and
+// left-over ones deleted. When an existing message was deleted, it and every
+// message after it are replaced so the pages stay in order. Any other error
+// leaves the messages alone, Discord being busy is no reason to repost. The image,
+// if any, is attached to the first message and replaces the previous one. The
+// components, if any, go below the last message. It returns the message IDs
+// to remember for the next sync.
+func syncPagedMessages(s *discordgo.Session, channelID string, messageIDs []string, embeds []*discordgo.MessageEmbed, img *pageImage, components []discordgo.MessageComponent) ([]string, error) {
+	newIDs := make([]string, 0, len(embeds))
+
+	if img != nil && len(embeds) > 0 {
+		embeds[0].Image = &discordgo.MessageEmbedImage{URL: "attachment://" + img.Name}
+	}
+
+	for i, embed := range embeds {
+		var files []*discordgo.File
+		if i == 0 && img != nil {
+			files = []*discordgo.File{img.file()}
+		}
+
+		// Editing with no components removes buttons left from when there were fewer pages
+		pageComponents := []discordgo.MessageComponent{}
+		if i == len(embeds)-1 && components != nil {
+			pageComponents = components
+		}
+
+		if i < len(messageIDs) {
+			// Dropping the old attachments makes sure an outdated image doesn't stick around
+			_, err := s.ChannelMessageEditComplex(&discordgo.MessageEdit{
+				ID:          messageIDs[i],
+				Channel:     channelID,
+				Embeds:      &[]*discordgo.MessageEmbed{embed},
+				Files:       files,
+				Attachments: &[]*discordgo.MessageAttachment{},
+				Components:  &pageComponents,
+			})
+			if err == nil {
+				newIDs = append(newIDs, messageIDs[i])
+				continue
+			}
+			if !isUnknownMessage(err) {
+				// The messages are still there, the next sync edits them again
+				return append(newIDs, messageIDs[i:]...), fmt.Errorf("error editing message %d of %d: %w", i+1, len(embeds), err)
+			}
+
+			// The message is gone, start over from here
+			deleteMessages(s, channelID, messageIDs[i:])
+			messageIDs = messageIDs[:i]
+		}
+
+		if i == 0 && img != nil {
+			// The reader may have been used up by a failed edit
+			files = []*discordgo.File{img.file()}
+		}
+
+		message, err := s.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Embeds:     []*discordgo.MessageEmbed{embed},
+			Files:      files,
+			Components: pageComponents,
+		})
+		if err != nil {
+			return newIDs, fmt.Errorf("error sending message %d of %d: %w", i+1, len(embeds), err)
+		}
+		newIDs = append(newIDs, message.ID)
+	}
+
+	if len(messageIDs) > len(embeds) {
+		deleteMessages(s, channelID, messageIDs[len(embeds):])
+	}
+
+	return newIDs, nil
+}
+
+// isUnknownMessage reports whether err is Discord saying the message doesn't exist.
+func isUnknownMessage(err error) bool {
+	var restErr *discordgo.RESTError
+	if !errors.As(err, &restErr) {
+		return false
+	}
+	if restErr.Message != nil {
+		return restErr.Message.Code == discordgo.ErrCodeUnknownMessage
+	}
+	return restErr.Response != nil && restErr.Response.StatusCode == http.StatusNotFound
+}
+
+func deleteMessages(s *discordgo.Session, channelID string, messageIDs []string) {
+	for _, messageID := range messageIDs {
+		// Already deleted messages are fine, we only want them gone
+		_ = s.ChannelMessageDelete(channelID, messageID)
+	}
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestPaginateFitsOnePage(t *testing.T) {
+	pages := paginate("header\n", []string{"a\n", "b\n"}, "trailer")
+	if len(pages) != 1 || pages[0] != "header\na\nb\ntrailer" {
+		t.Errorf("paginate = %q", pages)
+	}
+}
+
+func TestPaginateEmpty(t *testing.T) {
+	pages := paginate("", nil, "")
+	if len(pages) != 1 || pages[0] != "" {
+		t.Errorf("paginate = %q, want a single empty page", pages)
+	}
+}
+
+func TestPaginateSplitsBetweenBlocks(t *testing.T) {
+	block := strings.Repeat("é", 999) + "\n" // 1000 runes, more bytes
+	blocks := make([]string, 10)
+	for i := range blocks {
+		blocks[i] = block
+	}
+
+	pages := paginate("header\n", blocks, "trailer")
+	if len(pages) != 3 {
+		t.Fatalf("got %d pages, want 3", len(pages))
+	}
+
+	joined := strings.Join(pages, "")
+	if joined != "header\n"+strings.Repeat(block, 10)+"trailer" {
+		t.Error("pages don't add up to the header, blocks and trailer")
+	}
+	for i, page := range pages {
+		if n := utf8.RuneCountInString(page); n > embedDescriptionLimit {
+			t.Errorf("page %d has %d runes", i, n)
+		}
+		if !strings.HasSuffix(page, "\n") && i < len(pages)-1 {
+			t.Errorf("page %d splits a block", i)
+		}
+	}
+	if !strings.HasPrefix(pages[0], "header\n") || !strings.HasSuffix(pages[2], "trailer") {
+		t.Error("header or trailer ended up on the wrong page")
+	}
+}
+
+func TestPaginateTrailerGetsOwnPage(t *testing.T) {
+	block := strings.Repeat("x", embedDescriptionLimit-10)
+	pages := paginate("", []string{block}, strings.Repeat("t", 20))
+	if len(pages) != 2 || pages[0] != block {
+		t.Errorf("got %d pages, want the trailer on a second page", len(pages))
+	}
+}
+
+func TestPageOfBlock(t *testing.T) {
+	block := strings.Repeat("x", 1500)
+	pages := paginateBlocks("", []string{block, block, block, block, block}, "")
+
+	want := []int{0, 0, 1, 1, 2}
+	for i, page := range want {
+		if got := pageOfBlock(pages, i); got != page {
+			t.Errorf("block %d is on page %d, want %d", i, got, page)
+		}
+	}
+}
+
+func TestIsUnknownMessage(t *testing.T) {
+	restError := func(status, code int) error {
+		err := &discordgo.RESTError{Response: &http.Response{StatusCode: status}}
+		if code != 0 {
+			err.Message = &discordgo.APIErrorMessage{Code: code}
+		}
+		return fmt.Errorf("editing: %w", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deleted message", restError(http.StatusNotFound, discordgo.ErrCodeUnknownMessage), true},
+		{"not found without a code", restError(http.StatusNotFound, 0), true},
+		{"deleted channel", restError(http.StatusNotFound, discordgo.ErrCodeUnknownChannel), false},
+		{"rate limited", restError(http.StatusTooManyRequests, 0), false},
+		{"server error", restError(http.StatusBadGateway, 0), false},
+		{"network error", errors.New("connection reset"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isUnknownMessage(tt.err); got != tt.want {
+			t.Errorf("%s: isUnknownMessage = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"misclicked-events/internal/constants"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+// postPodiumAnnouncement celebrates the end of an event in the announcement
+// channel, if one is configured.
+func postPodiumAnnouncement(s *discordgo.Session, guildID string, result data.CompetitionResult) {
+	config, err := data.GetBotConfig(guildID)
+	if err != nil || config.AnnouncementChannelID == "" {
+		return
+	}
+
+	embed := podiumEmbed(i18n.ForGuild(guildID), result)
+	announcement := &discordgo.MessageSend{
+		Embeds: []*discordgo.MessageEmbed{embed},
+	}
+
+	// Show how the event went, the snapshots are kept until the next event starts
+	snapshots, err := data.GetStandingsSnapshots(guildID)
+	if err != nil {
+		utils.LogError("Error fetching standings snapshots", err)
+	}
+	if len(snapshots) > 0 && snapshots[0].Activity == result.Activity {
+		if img := participantsChart(s, guildID, snapshots, defaultChartTop); img != nil {
+			announcement.Files = []*discordgo.File{img.file()}
+			embed.Image = &discordgo.MessageEmbedImage{URL: "attachment://" + img.Name}
+		}
+	}
+
+	// Only the configured role gets pinged, never the winners or everyone
+	if config.AnnouncementRoleID != "" {
+		announcement.Content = fmt.Sprintf("<@&%s>", config.AnnouncementRoleID)
+		announcement.AllowedMentions = &discordgo.MessageAllowedMentions{
+			Roles: []string{config.AnnouncementRoleID},
+		}
+	} else {
+		announcement.AllowedMentions = &discordgo.MessageAllowedMentions{}
+	}
+
+	_, err = s.ChannelMessageSendComplex(config.AnnouncementChannelID, announcement)
+	if err != nil {
+		utils.LogError("Error sending podium announcement", err)
+	}
+}
+
+func podiumEmbed(p *message.Printer, result data.CompetitionResult) *discordgo.MessageEmbed {
+	activity := constants.Activities[result.Activity]
+
+	embed := &discordgo.MessageEmbed{
+		Title: p.Sprintf("🎉 %s event has ended!", result.Activity),
+		Color: 0xffd700, // Gold for the winners
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%s - %s", result.StartedAt.Format("Jan 02"), result.EndedAt.Format("Jan 02, 2006")),
+		},
+	}
+	if activity.BossThumbnail != "" {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: activity.BossThumbnail}
+	}
+
+	totalKC := 0
+	qualified := 0
+	var mvp data.StandingAccount
+	mvpOwner := ""
+	podium := ""
+
+	for _, standing := range result.Standings {
+		totalKC += standing.TotalKC
+		for _, account := range standing.Accounts {
+			if account.KC > mvp.KC {
+				mvp = account
+				mvpOwner = standing.DiscordId
+			}
+		}
+
+		if standing.Rank == 0 {
+			continue
+		}
+		qualified++
+
+		// Ties share a rank, so the podium can hold more than three people
+		if standing.Rank <= 3 {
+			podium += p.Sprintf("%s **<@%s>** - `%d` KC, _+%d pts_\n",
+				placementEmoji(standing.Rank), standing.DiscordId, standing.TotalKC, standing.Points)
+		}
+	}
+
+	if podium == "" {
+		embed.Description = p.Sprintf("Nobody reached the threshold of %dkc this time.", activity.Threshold)
+	} else {
+		winner := result.Standings[0]
+		embed.Description = p.Sprintf("Congratulations to <@%s> for winning the event with `%d` KC! 👑\n\n### Podium\n%s",
+			winner.DiscordId, winner.TotalKC, podium)
+	}
+
+	embed.Fields = []*discordgo.MessageEmbedField{
+		{
+			Name:   p.Sprintf("Total clan KC"),
+			Value:  p.Sprintf("`%d`", totalKC),
+			Inline: true,
+		},
+		{
+			Name:   p.Sprintf("Reached the threshold"),
+			Value:  p.Sprintf("%d of %d (%dkc)", qualified, len(result.Standings), activity.Threshold),
+			Inline: true,
+		},
+	}
+	if mvpOwner != "" {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   p.Sprintf("MVP account"),
+			Value:  p.Sprintf("**%s** (<@%s>) - `%d` KC", mvp.Name, mvpOwner, mvp.KC),
+			Inline: true,
+		})
+	}
+
+	return embed
+}
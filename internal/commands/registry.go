@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CommandHandler runs a command after its response has been deferred and the
+// member's permissions have been checked. A returned error is shown to the
+// member as an error embed, so it should already be translated.
+type CommandHandler func(s *discordgo.Session, i *discordgo.InteractionCreate) error
+
+// Middleware wraps the handler of a command with behaviour every command shares.
+type Middleware func(command Command, next CommandHandler) CommandHandler
+
+// Permission is what a member needs to be allowed to run a command.
+type Permission int
+
+const (
+	PermissionEveryone Permission = iota
+	// PermissionOrganizer is for admins and members with an organizer role.
+	PermissionOrganizer
+	PermissionAdmin
+)
+
+// defaultMemberPermissions is who Discord shows the command to before the
+// server changes it. Organizer roles are picked per server, so commands for
+// organizers are shown to everyone and checked when they're run.
+func (permission Permission) defaultMemberPermissions() *int64 {
+	if permission != PermissionAdmin {
+		return nil
+	}
+
+	manageServer := int64(discordgo.PermissionManageServer)
+	return &manageServer
+}
+
+// Command ties a slash command to everything needed to register and run it.
+type Command struct {
+	Definition   *discordgo.ApplicationCommand
+	Handler      CommandHandler
+	Autocomplete func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	Permission   Permission
+	// Public commands answer in the channel, the others only to the member.
+	Public bool
+}
+
+// registry holds every command of the bot, in the order they're registered.
+var registry = []Command{
+	{Definition: ConfigCommand, Handler: HandleConfigCommand, Permission: PermissionAdmin},
+	{Definition: TrackAccountCommand, Handler: HandleTrackNewAccountCommand},
+	{Definition: UntrackAccountCommand, Handler: HandleUnTrackAccountCommand, Autocomplete: HandleOwnAccountAutocomplete},
+	{Definition: TrackedAccountsCommand, Handler: HandleTrackedAccountsCommand},
+	{Definition: StartActivityCommand, Handler: HandleStartActivityCommand, Permission: PermissionOrganizer, Public: true},
+	{Definition: EndActivityCommand, Handler: HandleEndActivityCommand, Permission: PermissionOrganizer, Public: true},
+	{Definition: RenameAccountCommand, Handler: HandleRenameAccountCommand, Autocomplete: HandleOwnAccountAutocomplete},
+	{Definition: StatsCommand, Handler: HandleStatsCommand},
+	{Definition: LeaderboardCommand, Handler: HandleLeaderboardCommand},
+	{Definition: LanguageCommand, Handler: HandleLanguageCommand, Permission: PermissionAdmin},
+	{Definition: ChartCommand, Handler: HandleChartCommand},
+	{Definition: NotificationsCommand, Handler: HandleNotificationsCommand},
+	{Definition: ExportCommand, Handler: HandleExportCommand, Autocomplete: HandleExportAutocomplete, Permission: PermissionAdmin},
+	{Definition: WebhooksCommand, Handler: HandleWebhooksCommand, Autocomplete: HandleWebhookAutocomplete, Permission: PermissionAdmin},
+	{Definition: AdminCommand, Handler: HandleAdminCommand, Autocomplete: HandleAdminAccountAutocomplete, Permission: PermissionAdmin},
+	{Definition: VerificationCommand, Handler: HandleVerificationCommand, Permission: PermissionAdmin},
+	{Definition: VerifyCommand, Handler: HandleVerifyCommand, Autocomplete: HandleOwnAccountAutocomplete},
+	{Definition: OrganizersCommand, Handler: HandleOrganizersCommand, Permission: PermissionAdmin},
+	{Definition: KCReviewsCommand, Handler: HandleKCReviewsCommand, Autocomplete: HandleKCReviewAutocomplete, Permission: PermissionAdmin},
+}
+
+// middleware runs from the outside in, the last one runs right before the
+// handler.
+var middleware = []Middleware{
+	timeCommand,
+	deferResponse,
+	recoverPanic,
+	checkPermission,
+}
+
+// slowCommandThreshold is how long a command may take before it's logged.
+const slowCommandThreshold = 5 * time.Second
+
+func findCommand(name string) (Command, bool) {
+	index := slices.IndexFunc(registry, func(command Command) bool {
+		return command.Definition.Name == name
+	})
+	if index < 0 {
+		return Command{}, false
+	}
+	return registry[index], true
+}
+
+// HandleCommand runs the slash command the interaction is for.
+func HandleCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+
+	command, ok := findCommand(i.ApplicationCommandData().Name)
+	if !ok {
+		utils.LogError("Unknown command", fmt.Errorf("/%s", i.ApplicationCommandData().Name))
+		return
+	}
+
+	handler := command.Handler
+	for j := len(middleware) - 1; j >= 0; j-- {
+		handler = middleware[j](command, handler)
+	}
+
+	err := handler(s, i)
+	if err != nil {
+		utils.EditResponseError(s, i, err)
+	}
+}
+
+// HandleAutocomplete suggests values for the option the member is typing in.
+func HandleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommandAutocomplete {
+		return
+	}
+
+	command, ok := findCommand(i.ApplicationCommandData().Name)
+	if !ok || command.Autocomplete == nil {
+		utils.LogError("Unknown autocomplete", fmt.Errorf("/%s", i.ApplicationCommandData().Name))
+		return
+	}
+
+	// Autocomplete can't be answered in DMs without a member to look up
+	if i.Member == nil {
+		return
+	}
+
+	command.Autocomplete(s, i)
+}
+
+func timeCommand(command Command, next CommandHandler) CommandHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+		start := time.Now()
+		err := next(s, i)
+
+		if elapsed := time.Since(start); elapsed > slowCommandThreshold {
+			utils.LogError(fmt.Sprintf("Slow command /%s in guild %s took %s", command.Definition.Name, i.GuildID, elapsed.Round(time.Millisecond)), nil)
+		}
+		return err
+	}
+}
+
+func deferResponse(command Command, next CommandHandler) CommandHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+		response := &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		}
+		if !command.Public {
+			response.Data = &discordgo.InteractionResponseData{
+				Flags: discordgo.MessageFlagsEphemeral,
+			}
+		}
+
+		err := s.InteractionRespond(i.Interaction, response)
+		if err != nil {
+			// Without a deferred response there is nothing left to answer with
+			utils.LogError("Error deferring response", err)
+			return nil
+		}
+
+		return next(s, i)
+	}
+}
+
+func recoverPanic(command Command, next CommandHandler) CommandHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				utils.LogError(fmt.Sprintf("Panic in /%s", command.Definition.Name), fmt.Errorf("%v\n%s", r, debug.Stack()))
+				err = errors.New(i18n.ForGuild(i.GuildID).Sprintf("something went wrong while running this command"))
+			}
+		}()
+
+		return next(s, i)
+	}
+}
+
+func checkPermission(command Command, next CommandHandler) CommandHandler {
+	return func(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+		p := i18n.ForGuild(i.GuildID)
+
+		// Everything the bot tracks belongs to a server
+		if i.Member == nil {
+			return errors.New(p.Sprintf("this command can only be used in a server"))
+		}
+
+		allowed := true
+		switch command.Permission {
+		case PermissionOrganizer:
+			allowed = utils.IsAdmin(i) || data.IsOrganizer(i.GuildID, i.Member.Roles)
+		case PermissionAdmin:
+			allowed = utils.IsAdmin(i)
+		}
+		if !allowed {
+			return errors.New(p.Sprintf("you don't have the required permissions"))
+		}
+
+		return next(s, i)
+	}
+}
+
+// optionsByName looks up options by their name, optional options that were
+// left empty are missing from the map.
+func optionsByName(options []*discordgo.ApplicationCommandInteractionDataOption) map[string]*discordgo.ApplicationCommandInteractionDataOption {
+	byName := map[string]*discordgo.ApplicationCommandInteractionDataOption{}
+	for _, option := range options {
+		byName[option.Name] = option
+	}
+	return byName
+}
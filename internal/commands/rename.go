@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"fmt"
+	"errors"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/service"
 	"misclicked-events/internal/utils"
 
@@ -14,10 +15,11 @@ var RenameAccountCommand = &discordgo.ApplicationCommand{
 	Description: "Rename one of your tracked OSRS accounts",
 	Options: []*discordgo.ApplicationCommandOption{
 		{
-			Type:        discordgo.ApplicationCommandOptionString,
-			Name:        "old_username",
-			Description: "The current username of the account",
-			Required:    true,
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "old_username",
+			Description:  "The current username of the account",
+			Required:     true,
+			Autocomplete: true,
 		},
 		{
 			Type:        discordgo.ApplicationCommandOptionString,
@@ -28,38 +30,21 @@ var RenameAccountCommand = &discordgo.ApplicationCommand{
 	},
 }
 
-func HandleRenameAccountCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Defer the response immediately
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		utils.LogError("Error deferring response", err)
-		return
-	}
-
-	options := i.ApplicationCommandData().Options
-	if len(options) < 2 {
-		utils.EditResponseError(s, i, fmt.Errorf("please provide both the old and new usernames"))
-		return
-	}
+func HandleRenameAccountCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
 
-	oldUsername := options[0].StringValue()
-	newUsername := options[1].StringValue()
+	options := optionsByName(i.ApplicationCommandData().Options)
+	oldUsername := options["old_username"].StringValue()
+	newUsername := options["new_username"].StringValue()
 
 	// Verify the new username exists in OSRS
 	if !service.CheckIfPlayerExists(newUsername) {
-		utils.EditResponseError(s, i, fmt.Errorf("could not find an OSRS account with the username: %s", newUsername))
-		return
+		return errors.New(p.Sprintf("could not find an OSRS account with the username: %s", newUsername))
 	}
 
-	err = data.RenameAccount(i.GuildID, oldUsername, newUsername, i.Member.User.ID)
+	err := data.RenameAccount(i.GuildID, oldUsername, newUsername, i.Member.User.ID)
 	if err != nil {
-		utils.EditResponseError(s, i, fmt.Errorf("could not rename the account: %w", err))
-		return
+		return errors.New(p.Sprintf("could not rename the account: %v", errorReason(p, err)))
 	}
 
 	// Update the hiscore message if there's an ongoing event
@@ -70,6 +55,8 @@ func HandleRenameAccountCommand(s *discordgo.Session, i *discordgo.InteractionCr
 		}
 	}
 
-	response := fmt.Sprintf("Successfully renamed account from **%s** to **%s**", oldUsername, newUsername)
+	response := p.Sprintf("Successfully renamed account from **%s** to **%s**", oldUsername, newUsername)
 	utils.EditResponseMessage(s, i, response)
+
+	return nil
 }
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+var (
+	schemaAlertsMu sync.Mutex
+	// schemaAlerts holds the missing boss names each guild was last alerted about
+	schemaAlerts = map[string][]string{}
+)
+
+// alertSchemaProblems tells the admins when the hiscores stop listing boss
+// names the activity catalog relies on. It only alerts when the set of
+// missing names changes, and again once a response shows everything is back.
+func alertSchemaProblems(s *discordgo.Session, guildID string, report data.KCUpdateReport) {
+	// Without a single checked response nothing is known to be back
+	if report.Validated == 0 {
+		return
+	}
+
+	schemaAlertsMu.Lock()
+	previous := schemaAlerts[guildID]
+	if slices.Equal(previous, report.MissingBossNames) {
+		schemaAlertsMu.Unlock()
+		return
+	}
+	if len(report.MissingBossNames) == 0 {
+		delete(schemaAlerts, guildID)
+	} else {
+		schemaAlerts[guildID] = report.MissingBossNames
+	}
+	schemaAlertsMu.Unlock()
+
+	config, err := data.GetBotConfig(guildID)
+	if err != nil || config.AdminChannelID == "" {
+		return
+	}
+
+	p := i18n.ForGuild(guildID)
+
+	var embed *discordgo.MessageEmbed
+	if len(report.MissingBossNames) == 0 {
+		embed = &discordgo.MessageEmbed{
+			Title:       p.Sprintf("✅ Hiscores look normal again"),
+			Color:       0x33cc33,
+			Description: p.Sprintf("Every boss name in the activity catalog is back on the hiscores, KC updates have resumed."),
+		}
+	} else {
+		description := p.Sprintf(
+			"The hiscores no longer list these boss names:\n• %s\n\n",
+			strings.Join(report.MissingBossNames, "\n• "),
+		)
+		if report.Blocked {
+			description += p.Sprintf("They're part of the current event, so KC updates are paused until the activity catalog is fixed.")
+		} else {
+			description += p.Sprintf("The current event isn't affected, but activities using them need fixing before they're started.")
+		}
+
+		embed = &discordgo.MessageEmbed{
+			Title:       p.Sprintf("🚨 Hiscore format changed"),
+			Color:       0xff0000,
+			Description: description,
+		}
+	}
+
+	_, err = s.ChannelMessageSendEmbed(config.AdminChannelID, embed)
+	if err != nil {
+		utils.LogError("Error sending hiscore schema alert", err)
+	}
+}
+
+// updatesPausedNotice returns a line for the leaderboard when KC updates for
+// the current event are paused, or an empty string.
+func updatesPausedNotice(p *message.Printer, guildID string, bossNames []string) string {
+	schemaAlertsMu.Lock()
+	defer schemaAlertsMu.Unlock()
+
+	var missing []string
+	for _, name := range schemaAlerts[guildID] {
+		if slices.Contains(bossNames, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return ""
+	}
+
+	return p.Sprintf("\n⏸️ **KC updates are paused:** the hiscores no longer list *%s*.\n", strings.Join(missing, ", "))
+}
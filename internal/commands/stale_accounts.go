@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// notifyStaleAccounts lets the owners of newly stale accounts know by DM and
+// sends a report to the admin channel, if one is configured.
+func notifyStaleAccounts(s *discordgo.Session, guildID string, accounts []data.StaleAccount) {
+	if len(accounts) == 0 {
+		return
+	}
+
+	p := i18n.ForGuild(guildID)
+
+	for _, account := range accounts {
+		embed := &discordgo.MessageEmbed{
+			Title: p.Sprintf("⚠️ Account not found on the hiscores"),
+			Color: 0xFFA500,
+			Description: p.Sprintf(
+				"We haven't been able to find **%s** on the OSRS hiscores for the last %d updates.\n\n"+
+					"If you renamed the account, use `/rename` so we can keep tracking it. "+
+					"Until then the leaderboard keeps its last known KC.",
+				account.AccountName, data.StaleAfterFailedUpdates,
+			),
+		}
+
+		err := sendDirectMessage(s, account.DiscordId, embed)
+		if err != nil {
+			utils.LogError(fmt.Sprintf("Error sending stale account DM to %s", account.DiscordId), err)
+		}
+	}
+
+	config, err := data.GetBotConfig(guildID)
+	if err != nil || config.AdminChannelID == "" {
+		return
+	}
+
+	description := ""
+	for _, account := range accounts {
+		description += fmt.Sprintf("• **%s** (<@%s>)\n", account.AccountName, account.DiscordId)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       p.Sprintf("⚠️ Stale accounts"),
+		Color:       0xFFA500,
+		Description: p.Sprintf("These accounts were missing from the hiscores for %d updates in a row:\n\n%s", data.StaleAfterFailedUpdates, description),
+	}
+
+	_, err = s.ChannelMessageSendEmbed(config.AdminChannelID, embed)
+	if err != nil {
+		utils.LogError("Error sending stale account report", err)
+	}
+}
+
+func sendDirectMessage(s *discordgo.Session, discordId string, embed *discordgo.MessageEmbed) error {
+	channel, err := s.UserChannelCreate(discordId)
+	if err != nil {
+		return fmt.Errorf("error opening DM channel: %w", err)
+	}
+
+	_, err = s.ChannelMessageSendEmbed(channel.ID, embed)
+	if err != nil {
+		return fmt.Errorf("error sending DM: %w", err)
+	}
+
+	return nil
+}
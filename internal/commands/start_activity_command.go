@@ -1,9 +1,11 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"misclicked-events/internal/constants"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
 	"strings"
 
@@ -39,67 +41,44 @@ var StartActivityCommand = &discordgo.ApplicationCommand{
 		{
 			Type:        discordgo.ApplicationCommandOptionString,
 			Name:        "password",
-			Description: "Set an activity password",
-			Required:    true,
+			Description: "Set an activity password, needed to end it by admins who aren't organizers",
 		},
 	},
 }
 
-func HandleStartActivityCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	if !utils.IsAdmin(i) {
-		utils.RespondWithError(s, i, fmt.Errorf("you do not have the required permissions to use this command"))
-		return
-	}
+func HandleStartActivityCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
 
 	currentBoss := data.GetCurrentBoss(i.GuildID)
 	if len(currentBoss) > 0 {
-		response := fmt.Sprintf("An activity has already been selected: \"**%s**\", You need to end this activity before starting a new one.", currentBoss)
-		utils.RespondWithMessage(s, i, "%s", response)
-		return
+		return errors.New(p.Sprintf("An activity has already been selected: \"**%s**\", You need to end this activity before starting a new one.", currentBoss))
 	}
 
 	// Get the selected choice
-	choice := i.ApplicationCommandData().Options[0].StringValue()
-	password := i.ApplicationCommandData().Options[1].StringValue()
-
-	// Defer the response to indicate processing
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-	})
-	if err != nil {
-		fmt.Println("Error deferring the response:", err)
-		return
+	options := optionsByName(i.ApplicationCommandData().Options)
+	choice := options["choice"].StringValue()
+	password := ""
+	if option, ok := options["password"]; ok {
+		password = option.StringValue()
 	}
 
 	// Perform the long-running operation
-	err = data.StartCompetition(i.GuildID, choice, password)
+	err := data.StartCompetition(i.GuildID, choice, password)
 	if err != nil {
-		// Edit the deferred response to indicate an error
-		errorMessage := "Something went wrong trying to start this activity."
-		_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-			Content: &errorMessage,
-		})
-		if err != nil {
-			fmt.Println("Error editing interaction response:", err)
-		}
-		return
+		utils.LogError("Error starting competition", err)
+		return errors.New(p.Sprintf("Something went wrong trying to start this activity."))
 	}
 
 	updateCategoryChannelName(s, i.GuildID, choice)
 
 	// Edit the deferred response with the final result
-	successMessage := fmt.Sprintf(
+	utils.EditResponseMessage(s, i, p.Sprintf(
 		"Activity selected: **%s**, now tracking kc for: **%s**",
 		choice,
 		strings.Join(constants.Activities[choice].BossNames, ", "),
-	)
-	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Content: &successMessage,
-	})
-	if err != nil {
-		fmt.Println("Error editing interaction response:", err)
-	}
+	))
 
+	return nil
 }
 
 func updateCategoryChannelName(s *discordgo.Session, guildID, currentBoss string) {
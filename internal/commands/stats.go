@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"errors"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+// maxStatsLines keeps the fields within Discord's 1024 character field limit
+const maxStatsLines = 10
+
+var StatsCommand = &discordgo.ApplicationCommand{
+	Name:        "stats",
+	Description: "Show the competition record of a member",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionUser,
+			Name:        "user",
+			Description: "The member to show, yourself if left empty",
+			Required:    false,
+		},
+	},
+}
+
+func HandleStatsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	user := i.Member.User
+	if option, ok := optionsByName(i.ApplicationCommandData().Options)["user"]; ok {
+		user = option.UserValue(s)
+	}
+
+	p := i18n.ForGuild(i.GuildID)
+
+	stats, err := data.GetMemberStats(i.GuildID, user.ID)
+	if err != nil {
+		utils.LogError("Error fetching member stats", err)
+		return errors.New(p.Sprintf("something went wrong while fetching the stats"))
+	}
+
+	overallRank := p.Sprintf("_unranked_")
+	if stats.OverallRank > 0 {
+		overallRank = p.Sprintf("#%d", stats.OverallRank)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title: p.Sprintf("📊 Stats for %s", DisplayName(s, i.GuildID, user.ID)),
+		Color: 0x00ccff,
+		Thumbnail: &discordgo.MessageEmbedThumbnail{
+			URL: user.AvatarURL("128"),
+		},
+		Description: p.Sprintf(
+			"🏆 **%d** wins  •  🥇🥈🥉 **%d** podiums\n\n**Points:** `%d`  •  **Overall rank:** %s  •  **Events entered:** `%d`",
+			stats.Wins, stats.Podiums, stats.Points, overallRank, len(stats.Events),
+		),
+	}
+
+	if len(stats.Events) == 0 {
+		embed.Description += p.Sprintf("\n\nNo finished events yet, join the next one with `/track`!")
+	} else {
+		placements := ""
+		for index, event := range stats.Events {
+			if index == maxStatsLines {
+				placements += p.Sprintf("_... and %d more_\n", len(stats.Events)-maxStatsLines)
+				break
+			}
+			placements += p.Sprintf(
+				"%s **%s** (%s) - `%d` KC, %s\n",
+				placementEmoji(event.Rank), event.Activity, event.EndedAt.Format("Jan 2006"), event.TotalKC, placementText(p, event.Rank, event.Points),
+			)
+		}
+
+		best := ""
+		for _, activity := range stats.BestPerActivity {
+			rank := p.Sprintf("below threshold")
+			if activity.BestRank > 0 {
+				rank = p.Sprintf("#%d", activity.BestRank)
+			}
+			best += p.Sprintf("**%s:** %s, best `%d` KC\n", activity.Activity, rank, activity.BestKC)
+		}
+
+		accounts := ""
+		for index, account := range stats.Accounts {
+			if index == maxStatsLines {
+				accounts += p.Sprintf("_... and %d more_\n", len(stats.Accounts)-maxStatsLines)
+				break
+			}
+			accounts += p.Sprintf("🔹 **%s:** `%d` KC\n", account.AccountName, account.TotalKC)
+		}
+		if accounts == "" {
+			accounts = p.Sprintf("_No KC yet_")
+		}
+
+		embed.Fields = []*discordgo.MessageEmbedField{
+			{Name: p.Sprintf("Placements"), Value: placements},
+			{Name: p.Sprintf("Best per boss"), Value: best},
+			{Name: p.Sprintf("Account contributions"), Value: accounts},
+		}
+	}
+
+	// Edit the deferred response with the embed
+	embeds := []*discordgo.MessageEmbed{embed}
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &embeds,
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+
+	return nil
+}
+
+func placementEmoji(rank int) string {
+	switch rank {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🥈"
+	case 3:
+		return "🥉"
+	default:
+		return "▫️"
+	}
+}
+
+func placementText(p *message.Printer, rank, points int) string {
+	if rank == 0 {
+		return p.Sprintf("below threshold")
+	}
+	return p.Sprintf("#%d, +%d pts", rank, points)
+}
@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"fmt"
+	"errors"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
 
 	"github.com/bwmarrin/discordgo"
@@ -21,32 +22,25 @@ var TrackAccountCommand = &discordgo.ApplicationCommand{
 	},
 }
 
-func HandleTrackNewAccountCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Defer the response immediately
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		utils.LogError("Error deferring response", err)
-		return
-	}
+func HandleTrackNewAccountCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
 
-	options := i.ApplicationCommandData().Options
-	if len(options) < 1 {
-		utils.EditResponseError(s, i, fmt.Errorf("please provide a valid username"))
-		return
+	username := optionsByName(i.ApplicationCommandData().Options)["username"].StringValue()
+	err := data.TrackAccount(i.GuildID, username, i.Member.User.ID)
+	var owned *data.AccountOwnedError
+	if errors.As(err, &owned) {
+		respondWithOwnedAccount(s, i, owned)
+		return nil
 	}
-
-	username := options[0].StringValue()
-	err = data.TrackAccount(i.GuildID, username, i.Member.User.ID)
 	if err != nil {
-		utils.EditResponseError(s, i, fmt.Errorf("could not track the account '%s': %w", username, err))
-		return
+		return errors.New(p.Sprintf("could not track the account '%s': %v", username, errorReason(p, err)))
 	}
 
-	response := fmt.Sprintf("Successfully started tracking the OSRS account: **%s**", username)
+	response := p.Sprintf("Successfully started tracking the OSRS account: **%s**", username)
+	if data.IsVerificationRequired(i.GuildID) {
+		response += "\n\n" + p.Sprintf("🔒 Its KC only counts once you prove it's yours with `/verify`.")
+	}
 	utils.EditResponseMessage(s, i, response)
+
+	return nil
 }
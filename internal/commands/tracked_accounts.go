@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"fmt"
+	"errors"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
 
 	"github.com/bwmarrin/discordgo"
@@ -13,28 +14,18 @@ var TrackedAccountsCommand = &discordgo.ApplicationCommand{
 	Description: "accounts you're currently tracking",
 }
 
-func HandleTrackedAccountsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Defer the response immediately
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		utils.LogError("Error deferring response", err)
-		return
-	}
+func HandleTrackedAccountsCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
 
 	accounts, err := data.TrackedAccounts(i.GuildID, i.Member.User.ID)
-	if err != nil {
-		utils.EditResponseError(s, i, err)
-		return
+	if err != nil && !errors.Is(err, data.ErrNoTrackedAccounts) {
+		utils.LogError("Error fetching tracked accounts", err)
+		return errors.New(p.Sprintf("something went wrong, try again later"))
 	}
 
 	if len(accounts) == 0 {
-		utils.EditResponseMessage(s, i, "You have no tracked accounts at the moment. Use `/track` to start tracking one!")
-		return
+		utils.EditResponseMessage(s, i, p.Sprintf("You have no tracked accounts at the moment. Use `/track` to start tracking one!"))
+		return nil
 	}
 
 	currentCompetition := data.GetCurrentBoss(i.GuildID)
@@ -43,23 +34,32 @@ func HandleTrackedAccountsCommand(s *discordgo.Session, i *discordgo.Interaction
 	if len(currentCompetition) == 0 {
 		description = "\n"
 	} else {
-		description = fmt.Sprintf("**Event:** %s\n\n", currentCompetition)
+		description = p.Sprintf("**Event:** %s\n\n", currentCompetition)
 	}
 
 	for _, account := range accounts {
+		if account.Unverified {
+			description += p.Sprintf("🔒 **%s**\n   └ *Not verified yet, use `/verify` so its KC counts*\n\n", account.Name)
+			continue
+		}
+		if account.Stale {
+			description += p.Sprintf("⚠️ **%s**\n   └ *Not found on the hiscores, use `/rename` if you renamed it*\n\n", account.Name)
+			continue
+		}
+
 		if len(currentCompetition) > 0 {
 			activity, ok := account.Activities[currentCompetition]
 			if ok {
-				description += fmt.Sprintf(
+				description += p.Sprintf(
 					"🔹 **%s**\n   └ **KC**: `%d`\n\n",
 					account.Name,
-					activity.CurrentAmount-activity.StartAmount,
+					activity.KC(),
 				)
 			} else {
-				description += fmt.Sprintf("🔹 **%s**\n   └ *Not participating in the current event*\n", account.Name)
+				description += p.Sprintf("🔹 **%s**\n   └ *Not participating in the current event*\n", account.Name)
 			}
 		} else {
-			description += fmt.Sprintf("🔹 **%s**\n", account.Name)
+			description += p.Sprintf("🔹 **%s**\n", account.Name)
 		}
 	}
 
@@ -67,7 +67,7 @@ func HandleTrackedAccountsCommand(s *discordgo.Session, i *discordgo.Interaction
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: "https://runetracker.org/skills/overall.gif",
 		},
-		Title:       "Currently Tracked Accounts",
+		Title:       p.Sprintf("Currently Tracked Accounts"),
 		Description: description,
 		Color:       0x00ffcc,
 	}
@@ -80,4 +80,6 @@ func HandleTrackedAccountsCommand(s *discordgo.Session, i *discordgo.Interaction
 	if err != nil {
 		utils.LogError("Error editing response", err)
 	}
+
+	return nil
 }
@@ -1,8 +1,9 @@
 package commands
 
 import (
-	"fmt"
+	"errors"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
 
 	"github.com/bwmarrin/discordgo"
@@ -13,42 +14,25 @@ var UntrackAccountCommand = &discordgo.ApplicationCommand{
 	Description: "Untracks an OSRS account from your profile",
 	Options: []*discordgo.ApplicationCommandOption{
 		{
-			Type:        discordgo.ApplicationCommandOptionString,
-			Name:        "username",
-			Description: "The OSRS account username to stop tracking",
-			Required:    true,
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "username",
+			Description:  "The OSRS account username to stop tracking",
+			Required:     true,
+			Autocomplete: true,
 		},
 	},
 }
 
-func HandleUnTrackAccountCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	// Defer the response immediately
-	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Flags: discordgo.MessageFlagsEphemeral,
-		},
-	})
-	if err != nil {
-		utils.LogError("Error deferring response", err)
-		return
-	}
-
-	// Ensure the required option is provided
-	options := i.ApplicationCommandData().Options
-	if len(options) < 1 {
-		utils.EditResponseError(s, i, fmt.Errorf("please provide the OSRS account username you want to stop tracking"))
-		return
-	}
+func HandleUnTrackAccountCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
 
 	// Extract the username from the command
-	username := options[0].StringValue()
+	username := optionsByName(i.ApplicationCommandData().Options)["username"].StringValue()
 
 	// Attempt to untrack the account
-	err = data.UntrackAccount(i.GuildID, username, i.Member.User.ID)
+	err := data.UntrackAccount(i.GuildID, username, i.Member.User.ID)
 	if err != nil {
-		utils.EditResponseError(s, i, fmt.Errorf("could not untrack the account '%s': %w", username, err))
-		return
+		return errors.New(p.Sprintf("could not untrack the account '%s': %v", username, errorReason(p, err)))
 	}
 
 	//update the hiscore message
@@ -58,6 +42,8 @@ func HandleUnTrackAccountCommand(s *discordgo.Session, i *discordgo.InteractionC
 	}
 
 	// Respond with success message
-	response := fmt.Sprintf("Successfully stopped tracking the OSRS account: **%s**.", username)
+	response := p.Sprintf("Successfully stopped tracking the OSRS account: **%s**.", username)
 	utils.EditResponseMessage(s, i, response)
+
+	return nil
 }
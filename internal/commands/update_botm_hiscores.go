@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"misclicked-events/internal/constants"
 	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/service"
 	"misclicked-events/internal/utils"
 	"time"
 
@@ -26,23 +29,69 @@ func UpdateBOTMHiscores(s *discordgo.Session) {
 
 func updateUsers(s *discordgo.Session) {
 	for _, guild := range s.State.Guilds {
-		if ongoingEvent := checkOngoingEvent(guild.ID); ongoingEvent != "" {
-			err := data.UpdateAccountsKC(guild.ID)
-			if err != nil {
-				utils.LogError("Error when updating accounts", err)
-				continue
-			}
-
-			err = UpdateHiscoreMessage(s, guild.ID)
-			if err != nil {
-				utils.LogError("Error when updating hiscore message", err)
-			}
-		} else {
-			err := updateNoEventMessage(s, guild.ID)
-			if err != nil {
-				utils.LogError("Error when updating no-event message", err)
-			}
+		reportDuplicateAccounts(s, guild.ID)
+	}
+
+	if !service.ShouldAttemptUpdate() {
+		utils.LogError("OSRS hiscores are unavailable, skipping this update", nil)
+		return
+	}
+
+	for _, guild := range s.State.Guilds {
+		updateGuild(s, guild.ID)
+	}
+}
+
+// guildUpdates makes sure a refresh and the hourly update of a guild don't
+// post its leaderboard messages at the same time. The data layer guards the
+// data itself.
+var guildUpdates utils.KeyedMutex
+
+// updateGuild updates the KC of the guild's running event and its
+// leaderboard, or shows that there is no event.
+func updateGuild(s *discordgo.Session, guildID string) {
+	unlock := guildUpdates.Lock(guildID)
+	defer unlock()
+
+	if ongoingEvent := checkOngoingEvent(guildID); ongoingEvent == "" {
+		err := updateNoEventMessage(s, guildID)
+		if err != nil {
+			utils.LogError("Error when updating no-event message", err)
 		}
+		return
+	}
+
+	report, err := data.UpdateAccountsKC(guildID)
+	if errors.Is(err, service.ErrHiscoresUnavailable) {
+		// Put the outage banner on the board, the KC stays as it was
+		utils.LogError("Error when updating accounts", err)
+		err = UpdateHiscoreMessage(s, guildID)
+		if err != nil {
+			utils.LogError("Error when updating hiscore message", err)
+		}
+		return
+	}
+	if err != nil {
+		utils.LogError("Error when updating accounts", err)
+		return
+	}
+	markRefreshed(guildID)
+
+	err = data.RecordStandingsSnapshot(guildID)
+	if err != nil {
+		utils.LogError("Error when recording standings", err)
+	} else {
+		notifyStandingsChanges(s, guildID)
+		data.SendCompetitionUpdated(guildID)
+	}
+
+	notifyStaleAccounts(s, guildID, report.NewlyStale)
+	postKCReviews(s, guildID, report.NewReviews)
+	alertSchemaProblems(s, guildID, report)
+
+	err = UpdateHiscoreMessage(s, guildID)
+	if err != nil {
+		utils.LogError("Error when updating hiscore message", err)
 	}
 }
 
@@ -72,93 +121,78 @@ func UpdateHiscoreMessage(s *discordgo.Session, guildID string) error {
 		return fmt.Errorf("error fetching participants: %w", err)
 	}
 
-	// Build the embed
-	embed := &discordgo.MessageEmbed{
-		Title: "🏆 Killcount Leaderboard",
+	p := i18n.ForGuild(guildID)
+
+	// Build the embed every page is based on
+	embed := discordgo.MessageEmbed{
+		Title: p.Sprintf("🏆 Killcount Leaderboard"),
 		Color: 0xffd700, // Gold for leaderboard
 		Thumbnail: &discordgo.MessageEmbedThumbnail{
 			URL: constants.Activities[currentActivity].BossThumbnail, // Replace with a relevant boss icon
 		},
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("🔄 Last updated: %s", time.Now().Format("Jan 02, 2006 15:04:05 MST")),
+			Text: p.Sprintf("🔄 Last updated: %s", time.Now().Format("Jan 02, 2006 15:04:05 MST")),
 		},
 	}
 
+	// Don't pretend the data is current while the hiscores are down
+	header := ""
+	if health := service.Health(); !health.Available {
+		header = p.Sprintf(
+			"> ⚠️ **OSRS hiscores unavailable since %s**\n> KC is shown as it was before the outage.\n\n",
+			health.UnavailableSince.Format("Jan 02, 15:04 MST"),
+		)
+		embed.Footer.Text = p.Sprintf("🔄 Last updated: unknown")
+		if !health.LastSuccess.IsZero() {
+			embed.Footer.Text = p.Sprintf("🔄 Last updated: %s", health.LastSuccess.Format("Jan 02, 2006 15:04:05 MST"))
+		}
+	}
+
 	// Add information about tracked bosses
-	embed.Description = "### Tracked Bosses:\n"
+	header += p.Sprintf("### Tracked Bosses:\n")
 	bosses := constants.Activities[currentActivity].BossNames
 	for _, boss := range bosses {
-		embed.Description += fmt.Sprintf("• *%s*\n", boss)
+		header += fmt.Sprintf("• *%s*\n", boss)
 	}
+	header += updatesPausedNotice(p, guildID, bosses)
+
+	// Every participant is a block of its own so it never gets split over two pages
+	var blocks []string
+	trailer := ""
 
 	// Add leaderboard details
 	if len(participantKC) == 0 {
-		embed.Description += "\n🚨 No participants have enough KC yet!\n"
+		header += p.Sprintf("\n🚨 No participants have enough KC yet!\n")
 	} else {
-		embed.Description += "### Leaderboard:\n"
-		// Initialize rank tracking variables
-		rank := 0
-		previousKC := -1 // Set to a value that cannot match any valid TotalKC
-		currentRank := 1 // The rank being assigned to participants
-
-		for _, participant := range participantKC {
-			// Check if the current participant's TotalKC is different from the previous one
-			if participant.TotalKC != previousKC {
-				currentRank = rank + 1 // Update the current rank
-			}
-
-			// Assign appropriate emoji for ranks
-			var rankEmoji string
-			switch currentRank {
-			case 1:
-				rankEmoji = "🥇" // Gold Medal
-			case 2:
-				rankEmoji = "🥈" // Silver Medal
-			case 3:
-				rankEmoji = "🥉" // Bronze Medal
-			default:
-				rankEmoji = fmt.Sprintf("%d.", currentRank) // Numeric ranking for 4th and beyond
-			}
-
-			// Build account-specific details
-			accountDetails := ""
-			for _, account := range participant.AccountKCs {
-				accountDetails += fmt.Sprintf("\u00A0\u00A0\u00A0\u00A0 ┗ *%s: %d*\n", account.AccountName, account.TotalKC)
-			}
-
-			// Add the rank, mention, total KC, and account details to the description
-			embed.Description += fmt.Sprintf(
-				"%s **<@%s>** - **Total KC:** `%d`\n%s\n",
-				rankEmoji, participant.DiscordId, participant.TotalKC, accountDetails,
-			)
-
-			// Update rank and previousKC
-			rank++
-			previousKC = participant.TotalKC
+		header += p.Sprintf("### Leaderboard:\n")
+		// Compare against earlier standings to show who's been active
+		previous, dayAgo, err := data.PreviousStandings(guildID)
+		if err != nil {
+			utils.LogError("Error fetching previous standings", err)
 		}
 
-		embed.Description += fmt.Sprintf("_Threshold: %dkc_\n", constants.Activities[currentActivity].Threshold)
-	}
+		var hasStale bool
+		blocks, hasStale = leaderboardBlocks(p, participantKC, "", previous, dayAgo)
 
-	// Post or update the leaderboard message
-	if config.HiscoreMessageID != "" {
-		// Try to update the existing message
-		_, err := s.ChannelMessageEditEmbed(config.HiscoreChannelID, config.HiscoreMessageID, embed)
-		if err != nil {
-			// If editing fails, post a new message and update the message ID
-			newMessage, err := s.ChannelMessageSendEmbed(config.HiscoreChannelID, embed)
-			if err != nil {
-				return fmt.Errorf("error sending new leaderboard message: %w", err)
-			}
-			data.UpdateHiscoreMessageID(guildID, newMessage.ID)
+		trailer += p.Sprintf("_Threshold: %dkc_\n", constants.Activities[currentActivity].Threshold)
+		if previous != nil {
+			trailer += p.Sprintf("_(+KC since the last update, +KC in the last 24 hours)_\n")
 		}
-	} else {
-		// No previous message, post a new one
-		newMessage, err := s.ChannelMessageSendEmbed(config.HiscoreChannelID, embed)
-		if err != nil {
-			return fmt.Errorf("error sending leaderboard message: %w", err)
+		if hasStale {
+			trailer += p.Sprintf("_⚠️ stale: not found on the hiscores, showing the last known KC_\n")
 		}
-		data.UpdateHiscoreMessageID(guildID, newMessage.ID)
+	}
+
+	// Post or update the leaderboard messages
+	embeds := pagedEmbeds(embed, paginate(header, blocks, trailer))
+	img := hiscoreImage(s, guildID, currentActivity, participantKC, embed.Footer.Text)
+	buttons := leaderboardButtons(p, len(embeds) > 1)
+	messageIDs, err := syncPagedMessages(s, config.HiscoreChannelID, config.HiscoreMessageIDs, embeds, img, buttons)
+	if updateErr := data.UpdateHiscoreMessageIDs(guildID, messageIDs); updateErr != nil {
+		utils.LogError("Error saving hiscore message IDs", updateErr)
+	}
+	if err != nil {
+		return fmt.Errorf("error sending leaderboard messages: %w", err)
 	}
 
 	return nil
@@ -171,35 +205,25 @@ func updateNoEventMessage(s *discordgo.Session, guildID string) error {
 		return fmt.Errorf("error fetching bot configuration: %w", err)
 	}
 
+	p := i18n.ForGuild(guildID)
+
 	// Build the embed
 	embed := &discordgo.MessageEmbed{
-		Title:       "🚨 No Ongoing Event",
+		Title:       p.Sprintf("🚨 No Ongoing Event"),
 		Color:       0xFFA500, // Orange for no event
-		Description: "There is currently no ongoing event. Use the appropriate command to start a new event!",
+		Description: p.Sprintf("There is currently no ongoing event. Use the appropriate command to start a new event!"),
 		Footer: &discordgo.MessageEmbedFooter{
-			Text: fmt.Sprintf("🆕 Last updated: %s", time.Now().Format("Jan 02, 2006 15:04:05 MST")),
+			Text: p.Sprintf("🆕 Last updated: %s", time.Now().Format("Jan 02, 2006 15:04:05 MST")),
 		},
 	}
 
-	// Post or update the no-event message
-	if config.HiscoreMessageID != "" {
-		// Try to update the existing message
-		_, err := s.ChannelMessageEditEmbed(config.HiscoreChannelID, config.HiscoreMessageID, embed)
-		if err != nil {
-			// If editing fails, post a new message and update the message ID
-			newMessage, err := s.ChannelMessageSendEmbed(config.HiscoreChannelID, embed)
-			if err != nil {
-				return fmt.Errorf("error sending new no-event message: %w", err)
-			}
-			data.UpdateHiscoreMessageID(guildID, newMessage.ID)
-		}
-	} else {
-		// No previous message, post a new one
-		newMessage, err := s.ChannelMessageSendEmbed(config.HiscoreChannelID, embed)
-		if err != nil {
-			return fmt.Errorf("error sending no-event message: %w", err)
-		}
-		data.UpdateHiscoreMessageID(guildID, newMessage.ID)
+	// Post or update the no-event message, it only needs one page
+	messageIDs, err := syncPagedMessages(s, config.HiscoreChannelID, config.HiscoreMessageIDs, []*discordgo.MessageEmbed{embed}, nil, nil)
+	if updateErr := data.UpdateHiscoreMessageIDs(guildID, messageIDs); updateErr != nil {
+		utils.LogError("Error saving hiscore message IDs", updateErr)
+	}
+	if err != nil {
+		return fmt.Errorf("error sending no-event message: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"golang.org/x/text/message"
+)
+
+// VerifyButtonPrefix starts the custom ID of the button that checks a challenge.
+const VerifyButtonPrefix = "verify"
+
+var VerificationCommand = &discordgo.ApplicationCommand{
+	Name:        "verification",
+	Description: "Require members to prove they own an account before its KC counts",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionBoolean,
+			Name:        "required",
+			Description: "Whether newly tracked accounts need to be verified",
+			Required:    true,
+		},
+	},
+}
+
+var VerifyCommand = &discordgo.ApplicationCommand{
+	Name:        "verify",
+	Description: "Prove you own one of your tracked accounts so its KC counts",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:         discordgo.ApplicationCommandOptionString,
+			Name:         "username",
+			Description:  "The OSRS account to verify",
+			Required:     true,
+			Autocomplete: true,
+		},
+	},
+}
+
+func HandleVerificationCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	required := optionsByName(i.ApplicationCommandData().Options)["required"].BoolValue()
+	err := data.SetVerificationRequired(i.GuildID, required)
+	if errors.Is(err, data.ErrNoBotConfig) {
+		return errors.New(p.Sprintf("set up the channels with `/setup-channels` before changing the verification mode"))
+	}
+	if err != nil {
+		utils.LogError("Error saving verification mode", err)
+		return errors.New(p.Sprintf("something went wrong while changing the verification mode"))
+	}
+
+	if !required {
+		// Accounts waiting for verification count from now on
+		if ongoingEvent := checkOngoingEvent(i.GuildID); ongoingEvent != "" {
+			err = UpdateHiscoreMessage(s, i.GuildID)
+			if err != nil {
+				utils.LogError("Error updating hiscore message", err)
+			}
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("✅ Verification is off, every tracked account counts. Accounts more than one member tracks without a verification stay unverified until one of them passes `/verify`."))
+		return nil
+	}
+
+	utils.EditResponseMessage(s, i, p.Sprintf(
+		"✅ Verification is on. Accounts tracked from now on only count once their owner passes a challenge with `/verify`. Accounts that are already tracked keep counting.",
+	))
+
+	return nil
+}
+
+func HandleVerifyCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	username := optionsByName(i.ApplicationCommandData().Options)["username"].StringValue()
+	content, components := verifyAccount(s, i, username)
+
+	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &components,
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+
+	return nil
+}
+
+func HandleVerifyButton(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Custom IDs look like verify:check:<account name>
+	parts := strings.SplitN(i.MessageComponentData().CustomID, ":", 3)
+	if len(parts) != 3 || parts[1] != "check" {
+		utils.RespondWithError(s, i, errors.New(i18n.ForGuild(i.GuildID).Sprintf("unknown verification button")))
+		return
+	}
+
+	// Looking up the hiscores takes a moment
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	})
+	if err != nil {
+		utils.LogError("Error deferring verification check", err)
+		return
+	}
+
+	content, components := verifyAccount(s, i, parts[2])
+
+	_, err = s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Content:    &content,
+		Components: &components,
+	})
+	if err != nil {
+		utils.LogError("Error editing response", err)
+	}
+}
+
+// verifyAccount checks the pending challenge of the account, or hands out a
+// new one when there is none. It returns the message to show the member.
+func verifyAccount(s *discordgo.Session, i *discordgo.InteractionCreate, username string) (string, []discordgo.MessageComponent) {
+	p := i18n.ForGuild(i.GuildID)
+	noButtons := []discordgo.MessageComponent{}
+
+	verified, challenge, err := data.CheckVerification(i.GuildID, username, i.Member.User.ID)
+	switch {
+	case errors.Is(err, data.ErrAlreadyVerified):
+		return p.Sprintf("✅ **%s** is verified, its KC counts.", username), noButtons
+	case verified:
+		if ongoingEvent := checkOngoingEvent(i.GuildID); ongoingEvent != "" {
+			if err := UpdateHiscoreMessage(s, i.GuildID); err != nil {
+				utils.LogError("Error updating hiscore message", err)
+			}
+		}
+		return p.Sprintf("🎉 **%s** is verified, its KC counts from now on.", username), noButtons
+	case err != nil && !errors.Is(err, data.ErrChallengeExpired):
+		return p.Sprintf("⚠️ **Error**\n%s", p.Sprintf("could not verify the account '%s': %v", username, errorReason(p, err))), noButtons
+	case challenge != nil && err == nil:
+		// The challenge is still running, the XP just isn't there yet
+		return challengeMessage(p, username, *challenge, true), verifyButtons(p, username)
+	}
+
+	// No challenge yet, or the last one expired
+	newChallenge, err := data.StartVerification(i.GuildID, username, i.Member.User.ID)
+	var cooldown *data.ChallengeCooldownError
+	if errors.As(err, &cooldown) {
+		return p.Sprintf("⏳ The last challenge for **%s** ran out. You can try a new challenge <t:%d:R>.", username, cooldown.RetryAt.Unix()), noButtons
+	}
+	if errors.Is(err, data.ErrNoRankedSkill) {
+		return p.Sprintf("**%s** isn't on the hiscores in any of %s, so a challenge in them wouldn't show up. Train one of them until it's listed, then try again.", username, strings.Join(data.VerificationSkills(), ", ")), noButtons
+	}
+	if err != nil {
+		return p.Sprintf("⚠️ **Error**\n%s", p.Sprintf("could not verify the account '%s': %v", username, errorReason(p, err))), noButtons
+	}
+	return challengeMessage(p, username, newChallenge, false), verifyButtons(p, username)
+}
+
+func challengeMessage(p *message.Printer, username string, challenge data.VerificationChallenge, checked bool) string {
+	content := p.Sprintf(
+		"🔒 To prove **%s** is yours, gain at least **%d %s** XP on it before <t:%d:t> (<t:%d:R>). "+
+			"Log out afterwards so the hiscores catch up, then press the button below.",
+		username, challenge.XP, challenge.Skill, challenge.ExpiresAt.Unix(), challenge.ExpiresAt.Unix(),
+	)
+	if checked {
+		content = p.Sprintf("⏳ Not enough new %s XP on the hiscores yet.", challenge.Skill) + "\n\n" + content
+	}
+	return content
+}
+
+func verifyButtons(p *message.Printer, username string) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    p.Sprintf("Check now"),
+					Emoji:    &discordgo.ComponentEmoji{Name: "🔍"},
+					Style:    discordgo.PrimaryButton,
+					CustomID: fmt.Sprintf("%s:check:%s", VerifyButtonPrefix, username),
+				},
+			},
+		},
+	}
+}
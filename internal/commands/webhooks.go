@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"misclicked-events/internal/webhook"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var WebhooksCommand = &discordgo.ApplicationCommand{
+	Name:        "webhooks",
+	Description: "Manage the URLs that get competition events",
+	Options: []*discordgo.ApplicationCommandOption{
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "add",
+			Description: "Send competition events to a URL",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "url",
+					Description: "The URL to send the events to",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "remove",
+			Description: "Stop sending competition events to a URL",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionString,
+					Name:         "url",
+					Description:  "The URL to stop sending the events to",
+					Required:     true,
+					Autocomplete: true,
+				},
+			},
+		},
+		{
+			Type:        discordgo.ApplicationCommandOptionSubCommand,
+			Name:        "list",
+			Description: "Show the URLs that get competition events",
+		},
+	},
+}
+
+func HandleWebhooksCommand(s *discordgo.Session, i *discordgo.InteractionCreate) error {
+	p := i18n.ForGuild(i.GuildID)
+
+	subcommand := i.ApplicationCommandData().Options[0]
+	options := optionsByName(subcommand.Options)
+
+	switch subcommand.Name {
+	case "add":
+		hookURL := options["url"].StringValue()
+		err := webhook.ValidateURL(context.Background(), hookURL)
+		switch {
+		case errors.Is(err, webhook.ErrForbiddenHost):
+			return errors.New(p.Sprintf("webhooks can't point at private or local addresses"))
+		case err != nil:
+			return errors.New(p.Sprintf("please provide a valid http or https URL"))
+		}
+
+		secret, err := data.AddWebhook(i.GuildID, hookURL)
+		switch {
+		case errors.Is(err, data.ErrWebhookExists):
+			return errors.New(p.Sprintf("that URL already gets the competition events"))
+		case errors.Is(err, data.ErrTooManyWebhooks):
+			return errors.New(p.Sprintf("a server can have at most %d webhooks", data.MaxWebhooks))
+		case errors.Is(err, data.ErrNoBotConfig):
+			return errors.New(p.Sprintf("set up the channels with `/setup-channels` before adding webhooks"))
+		case err != nil:
+			utils.LogError("Error adding webhook", err)
+			return errors.New(p.Sprintf("something went wrong while adding the webhook"))
+		}
+
+		// The secret is only ever shown here
+		utils.EditResponseMessage(s, i, p.Sprintf(
+			"✅ Competition events are now sent to <%s>.\n\n"+
+				"Every delivery has a `%s` header with the HMAC-SHA256 of the body, signed with this secret. "+
+				"Store it somewhere safe, it won't be shown again:\n||`%s`||",
+			hookURL, webhook.SignatureHeader, secret,
+		))
+	case "remove":
+		hookURL := options["url"].StringValue()
+		err := data.RemoveWebhook(i.GuildID, hookURL)
+		if errors.Is(err, data.ErrWebhookNotFound) {
+			return errors.New(p.Sprintf("that URL doesn't get the competition events"))
+		}
+		if err != nil {
+			utils.LogError("Error removing webhook", err)
+			return errors.New(p.Sprintf("something went wrong while removing the webhook"))
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("✅ Competition events are no longer sent to <%s>.", hookURL))
+	case "list":
+		hooks := data.GetWebhooks(i.GuildID)
+		if len(hooks) == 0 {
+			utils.EditResponseMessage(s, i, p.Sprintf("No URLs get the competition events yet, add one with `/webhooks add`."))
+			return nil
+		}
+
+		list := ""
+		for _, hook := range hooks {
+			list += fmt.Sprintf("• <%s>\n", hook.URL)
+		}
+		utils.EditResponseMessage(s, i, p.Sprintf("These URLs get the competition events:\n%s", list))
+	}
+
+	return nil
+}
+
+// HandleWebhookAutocomplete suggests the guild's webhook URLs to remove.
+func HandleWebhookAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Only admins get to see the URLs
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	if utils.IsAdmin(i) {
+		for _, hook := range data.GetWebhooks(i.GuildID) {
+			// Choice names are limited to 100 characters, the value is what counts
+			choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+				Name:  truncateChoiceName(hook.URL),
+				Value: hook.URL,
+			})
+		}
+	}
+
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{
+			Choices: choices,
+		},
+	})
+	if err != nil {
+		utils.LogError("Error sending autocomplete choices", err)
+	}
+}
+
+func truncateChoiceName(name string) string {
+	if len(name) <= 100 {
+		return name
+	}
+	return name[:97] + "..."
+}
@@ -22,3 +22,16 @@ func GetToken() string {
 
 	return token
 }
+
+// GetHiscoreBaseURL returns the hiscore server override, if any.
+// GetToken has to be called first so the .env file is loaded.
+func GetHiscoreBaseURL() string {
+	return os.Getenv("HISCORE_BASE_URL")
+}
+
+// GetDashboardAddr returns the address the web dashboard listens on, the
+// dashboard is off when it's empty. GetToken has to be called first so the
+// .env file is loaded.
+func GetDashboardAddr() string {
+	return os.Getenv("DASHBOARD_ADDR")
+}
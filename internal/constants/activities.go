@@ -1,78 +1,111 @@
 package constants
 
+import "slices"
+
 var Activities = map[string]ActivityDetails{
 	"COLO": {
 		Threshold:     1,
+		MaxPerHour:    4,
 		BossNames:     []string{"Sol Heredit"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_solheredit.png?2",
 	},
 	"Corp": {
 		Threshold:     25,
+		MaxPerHour:    30,
 		BossNames:     []string{"Corporeal Beast"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_corporealbeast.png?2",
 	},
 	"Wildy": {
-		Threshold: 25,
-		BossNames: []string{"Artio", "Callisto", "Cal'varion", "Vet'ion", "Venenatis", "Spindel"},
+		Threshold:  25,
+		MaxPerHour: 60,
+		BossNames:  []string{"Artio", "Callisto", "Cal'varion", "Vet'ion", "Venenatis", "Spindel"},
 	},
 	"COX": {
 		Threshold:     5,
+		MaxPerHour:    6,
 		BossNames:     []string{"Chambers of Xeric", "Chambers of Xeric: Challenge Mode"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_chambersofxeric.png?2",
 	},
 	"Huey": {
 		Threshold:     25,
+		MaxPerHour:    20,
 		BossNames:     []string{"The Hueycoatl"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_thehueycoatl.png?2",
 	},
 	"Inferno": {
 		Threshold:     1,
+		MaxPerHour:    2,
 		BossNames:     []string{"TzKal-Zuk"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_tzkalzuk.png?2",
 	},
 	"Nex": {
 		Threshold:     25,
+		MaxPerHour:    20,
 		BossNames:     []string{"Nex"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_nex.png?2",
 	},
 	"NM": {
 		Threshold:     25,
+		MaxPerHour:    15,
 		BossNames:     []string{"Nightmare", "Phosani's Nightmare"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_nightmare.png?2",
 	},
 	"Sarachnis": {
 		Threshold:     25,
+		MaxPerHour:    60,
 		BossNames:     []string{"Sarachnis"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_sarachnis.png?2",
 	},
 	"TOA": {
 		Threshold:     5,
+		MaxPerHour:    6,
 		BossNames:     []string{"Tombs of Amascut", "Tombs of Amascut: Expert Mode"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_tombsofamascutexpertmode.png?2",
 	},
 	"TOB": {
 		Threshold:     5,
+		MaxPerHour:    6,
 		BossNames:     []string{"Theatre of Blood", "Theatre of Blood: Hard Mode"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_theatreofblood.png?2",
 	},
 	"Zulrah": {
 		Threshold:     25,
+		MaxPerHour:    40,
 		BossNames:     []string{"Zulrah"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_zulrah.png?2",
 	},
 	"DT2": {
-		Threshold: 25,
-		BossNames: []string{"Vardorvis", "Duke Sucellus", "The Whisperer", "The Leviathan"},
+		Threshold:  25,
+		MaxPerHour: 30,
+		BossNames:  []string{"Vardorvis", "Duke Sucellus", "The Whisperer", "The Leviathan"},
 	},
 	"MOKHA": {
 		Threshold:     1,
+		MaxPerHour:    10,
 		BossNames:     []string{"Doom of Mokhaiotl"},
 		BossThumbnail: "https://www.runescape.com/img/rsp777/game_icon_doomofmokhaiotl.png?2",
 	},
 }
 
+// BossNames returns every hiscore activity name used by the activities above.
+func BossNames() []string {
+	var names []string
+	for _, activity := range Activities {
+		for _, name := range activity.BossNames {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 type ActivityDetails struct {
-	Threshold     int
+	Threshold int
+	// MaxPerHour is the most KC an account can plausibly gain in an hour,
+	// anything above it is held for an admin to review.
+	MaxPerHour    int
 	BossNames     []string
 	BossThumbnail string
 }
@@ -0,0 +1,154 @@
+// Package dashboard serves read-only web pages with the standings of each
+// guild, for members that want to check them without opening Discord.
+package dashboard
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/i18n"
+	"misclicked-events/internal/utils"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/text/message"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+// cacheDuration is how long a rendered page is served before it's rebuilt.
+// Looking up member names can hit the Discord API, so pages aren't rebuilt
+// for every visitor.
+const cacheDuration = time.Minute
+
+// guildIDPattern matches Discord snowflakes, guild IDs end up in file paths
+var guildIDPattern = regexp.MustCompile(`^[0-9]{1,20}$`)
+
+// NameLookup returns the name a member goes by in a guild.
+type NameLookup func(guildID, discordId string) string
+
+type Server struct {
+	names NameLookup
+	pages map[string]*template.Template
+	mux   *http.ServeMux
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedPage
+}
+
+type cachedPage struct {
+	body    []byte
+	builtAt time.Time
+}
+
+// page is what every template gets, Content is the page specific part.
+type page struct {
+	P       *message.Printer
+	GuildID string
+	Active  string
+	Content any
+}
+
+// New sets up the dashboard, names is used to show members by name.
+func New(names NameLookup) (*Server, error) {
+	srv := &Server{
+		names: names,
+		pages: map[string]*template.Template{},
+		mux:   http.NewServeMux(),
+		cache: map[string]cachedPage{},
+	}
+
+	for _, name := range []string{"board", "ranking", "history"} {
+		tmpl, err := template.ParseFS(templateFiles, "templates/layout.html", "templates/"+name+".html")
+		if err != nil {
+			return nil, err
+		}
+		srv.pages[name] = tmpl
+	}
+
+	srv.mux.HandleFunc("GET /{guild}/{$}", srv.guildPage("board", srv.boardPage))
+	srv.mux.HandleFunc("GET /{guild}/ranking", srv.guildPage("ranking", srv.rankingPage))
+	srv.mux.HandleFunc("GET /{guild}/history", srv.guildPage("history", srv.historyPage))
+
+	return srv, nil
+}
+
+// ListenAndServe serves the dashboard until it fails.
+func ListenAndServe(addr string, names NameLookup) error {
+	srv, err := New(names)
+	if err != nil {
+		return err
+	}
+	return http.ListenAndServe(addr, srv)
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mux.ServeHTTP(w, r)
+}
+
+// guildPage serves a page of a guild that has been set up, built by build
+// and rendered with the named template.
+func (srv *Server) guildPage(name string, build func(guildID string) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guildID := r.PathValue("guild")
+		if !guildIDPattern.MatchString(guildID) {
+			http.NotFound(w, r)
+			return
+		}
+		if _, err := data.GetBotConfig(guildID); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		cacheKey := name + ":" + guildID
+		if body, ok := srv.cached(cacheKey); ok {
+			writePage(w, body)
+			return
+		}
+
+		p := i18n.ForGuild(guildID)
+		content, err := build(guildID)
+		if err != nil {
+			utils.LogError("Error building dashboard page", err)
+			http.Error(w, p.Sprintf("Something went wrong while loading this page."), http.StatusInternalServerError)
+			return
+		}
+
+		var body bytes.Buffer
+		err = srv.pages[name].ExecuteTemplate(&body, "layout", page{P: p, GuildID: guildID, Active: name, Content: content})
+		if err != nil {
+			utils.LogError("Error rendering dashboard page", err)
+			http.Error(w, p.Sprintf("Something went wrong while loading this page."), http.StatusInternalServerError)
+			return
+		}
+
+		srv.store(cacheKey, body.Bytes())
+		writePage(w, body.Bytes())
+	}
+}
+
+func (srv *Server) cached(key string) ([]byte, bool) {
+	srv.cacheMu.Lock()
+	defer srv.cacheMu.Unlock()
+
+	cached, ok := srv.cache[key]
+	if !ok || time.Since(cached.builtAt) > cacheDuration {
+		return nil, false
+	}
+	return cached.body, true
+}
+
+func (srv *Server) store(key string, body []byte) {
+	srv.cacheMu.Lock()
+	defer srv.cacheMu.Unlock()
+	srv.cache[key] = cachedPage{body: body, builtAt: time.Now()}
+}
+
+func writePage(w http.ResponseWriter, body []byte) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(body)
+}
@@ -0,0 +1,175 @@
+package dashboard
+
+import (
+	"misclicked-events/internal/constants"
+	"misclicked-events/internal/data"
+	"misclicked-events/internal/service"
+	"slices"
+	"time"
+)
+
+type boardContent struct {
+	Activity  string
+	Thumbnail string
+	BossNames []string
+	Threshold int
+	// Ranked reached the threshold, Below didn't yet.
+	Ranked []boardRow
+	Below  []boardRow
+	// HasGains is set when there are earlier standings to compare with.
+	HasGains bool
+	Health   service.HealthStatus
+}
+
+type boardRow struct {
+	Rank     int
+	Name     string
+	TotalKC  int
+	Gain     int
+	DayGain  int
+	Accounts []data.AccountKC
+}
+
+type rankingContent struct {
+	Rows []rankingRow
+}
+
+type rankingRow struct {
+	Rank   int
+	Name   string
+	Points int
+}
+
+type historyContent struct {
+	Events []historyEvent
+}
+
+type historyEvent struct {
+	Activity  string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Rows      []historyRow
+}
+
+type historyRow struct {
+	Rank    int
+	Name    string
+	TotalKC int
+	Points  int
+}
+
+// boardPage is the current event, like the leaderboard in the hiscore channel.
+func (srv *Server) boardPage(guildID string) (any, error) {
+	activity := data.GetCurrentBoss(guildID)
+	if activity == "" {
+		return boardContent{}, nil
+	}
+
+	participantKC, err := data.GetParticipantsByActivityKC(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, dayAgo, err := data.PreviousStandings(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	content := boardContent{
+		Activity:  activity,
+		Thumbnail: constants.Activities[activity].BossThumbnail,
+		BossNames: constants.Activities[activity].BossNames,
+		Threshold: constants.Activities[activity].Threshold,
+		HasGains:  previous != nil && dayAgo != nil,
+		Health:    service.Health(),
+	}
+
+	// Equal KC shares a rank
+	rank := 0
+	previousKC := -1
+	for i, participant := range participantKC {
+		row := boardRow{
+			Name:     srv.names(guildID, participant.DiscordId),
+			TotalKC:  participant.TotalKC,
+			Accounts: participant.AccountKCs,
+		}
+		if content.HasGains {
+			// Participants missing from a snapshot had no KC yet
+			previousEntry, _ := previous.Entry(participant.DiscordId)
+			dayAgoEntry, _ := dayAgo.Entry(participant.DiscordId)
+			row.Gain = participant.TotalKC - previousEntry.TotalKC
+			row.DayGain = participant.TotalKC - dayAgoEntry.TotalKC
+		}
+
+		if participant.TotalKC < content.Threshold {
+			content.Below = append(content.Below, row)
+			continue
+		}
+
+		if participant.TotalKC != previousKC {
+			rank = i + 1
+		}
+		previousKC = participant.TotalKC
+		row.Rank = rank
+		content.Ranked = append(content.Ranked, row)
+	}
+
+	return content, nil
+}
+
+// rankingPage is the points over all events, like the ranking channel.
+func (srv *Server) rankingPage(guildID string) (any, error) {
+	participants, err := data.GetParticipantsInOrder(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var content rankingContent
+	rank := 0
+	previousPoints := -1
+	for i, participant := range participants {
+		if participant.Points == 0 {
+			continue
+		}
+		if participant.Points != previousPoints {
+			rank = i + 1
+		}
+		previousPoints = participant.Points
+
+		content.Rows = append(content.Rows, rankingRow{
+			Rank:   rank,
+			Name:   srv.names(guildID, participant.DiscordId),
+			Points: participant.Points,
+		})
+	}
+
+	return content, nil
+}
+
+// historyPage is every event that has ended, the latest first.
+func (srv *Server) historyPage(guildID string) (any, error) {
+	history, err := data.GetCompetitionHistory(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	var content historyContent
+	for _, result := range slices.Backward(history) {
+		event := historyEvent{
+			Activity:  result.Activity,
+			StartedAt: result.StartedAt,
+			EndedAt:   result.EndedAt,
+		}
+		for _, standing := range result.Standings {
+			event.Rows = append(event.Rows, historyRow{
+				Rank:    standing.Rank,
+				Name:    srv.names(guildID, standing.DiscordId),
+				TotalKC: standing.TotalKC,
+				Points:  standing.Points,
+			})
+		}
+		content.Events = append(content.Events, event)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// AccountClaim is a member saying an account tracked by someone else is
+// theirs, it waits for an admin to settle it.
+type AccountClaim struct {
+	ID          string    `json:"id"`
+	AccountKey  string    `json:"accountKey"`
+	AccountName string    `json:"accountName"`
+	OwnerId     string    `json:"ownerId"`
+	ClaimantId  string    `json:"claimantId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+const claimsFilePath = "%s_claims.json"
+
+func getAccountClaims(guildID string) ([]AccountClaim, error) {
+	data, err := os.ReadFile(assetPath(claimsFilePath, guildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var claims []AccountClaim
+	err = json.Unmarshal(data, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return claims, nil
+}
+
+func saveAccountClaims(guildID string, claims []AccountClaim) error {
+	data, err := json.MarshalIndent(claims, "", "  ") // Pretty-print
+	if err != nil {
+		return fmt.Errorf("failed to marshal claims: %w", err)
+	}
+
+	err = os.WriteFile(assetPath(claimsFilePath, guildID), data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	return nil
+}
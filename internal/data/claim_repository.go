@@ -0,0 +1,226 @@
+package data
+
+import (
+	"cmp"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"golang.org/x/text/cases"
+)
+
+var (
+	// ErrClaimPending is returned when the member already claimed the account
+	// and an admin hasn't looked at it yet.
+	ErrClaimPending = errors.New("claim already pending")
+	// ErrAccountNotTracked is returned when nobody tracks the claimed account anymore.
+	ErrAccountNotTracked = errors.New("nobody is tracking this account")
+	ErrClaimHandled      = errors.New("this claim has already been handled")
+)
+
+// AccountOwnedError is returned when an account is tracked by another member,
+// every account can only count for one member.
+type AccountOwnedError struct {
+	AccountName string
+	OwnerId     string
+}
+
+func (e *AccountOwnedError) Error() string {
+	return fmt.Sprintf("%s is already tracked by <@%s>", e.AccountName, e.OwnerId)
+}
+
+// DuplicateAccount is an account that counts for more than one member.
+type DuplicateAccount struct {
+	AccountName string
+	OwnerIds    []string
+}
+
+// accountOwner returns who tracks the account. Members who haven't verified
+// it don't own it, anyone else may track it until one of them does.
+func accountOwner(participants map[string]Participant, username string) (string, OSRSAccount, bool) {
+	usernameKey := cases.Fold().String(username)
+	for discordId, participant := range participants {
+		if account, ok := participant.LinkedOSRSAccounts[usernameKey]; ok && !account.Unverified {
+			return discordId, account, true
+		}
+	}
+	return "", OSRSAccount{}, false
+}
+
+// releaseUnverifiedCopies takes the account away from everyone but ownerId
+// who tracks it without having verified it, now that ownerId owns it.
+func releaseUnverifiedCopies(participants map[string]Participant, username, ownerId string) {
+	usernameKey := cases.Fold().String(username)
+	for discordId, participant := range participants {
+		account, ok := participant.LinkedOSRSAccounts[usernameKey]
+		if discordId == ownerId || !ok || !account.Unverified {
+			continue
+		}
+
+		delete(participant.LinkedOSRSAccounts, usernameKey)
+		if len(participant.LinkedOSRSAccounts) == 0 && participant.Points == 0 {
+			delete(participants, discordId)
+		}
+	}
+}
+
+// FindDuplicateAccounts returns the accounts that more than one member has
+// verified, which slipped in before an account could only count once. Admins
+// have to settle them by hand.
+func FindDuplicateAccounts(guildID string) ([]DuplicateAccount, error) {
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	byKey := make(map[string]*DuplicateAccount)
+	for discordId, participant := range participants {
+		for key, account := range participant.LinkedOSRSAccounts {
+			if account.Unverified {
+				continue
+			}
+			if byKey[key] == nil {
+				byKey[key] = &DuplicateAccount{AccountName: account.Name}
+			}
+			byKey[key].OwnerIds = append(byKey[key].OwnerIds, discordId)
+		}
+	}
+
+	var duplicates []DuplicateAccount
+	for _, account := range byKey {
+		if len(account.OwnerIds) > 1 {
+			slices.Sort(account.OwnerIds)
+			duplicates = append(duplicates, *account)
+		}
+	}
+	slices.SortFunc(duplicates, func(a, b DuplicateAccount) int {
+		return cmp.Compare(a.AccountName, b.AccountName)
+	})
+
+	return duplicates, nil
+}
+
+// checkAccountOwner refuses accounts tracked by anyone but discordId.
+func checkAccountOwner(participants map[string]Participant, username, discordId string) error {
+	owner, account, ok := accountOwner(participants, username)
+	if ok && owner != discordId {
+		return &AccountOwnedError{AccountName: account.Name, OwnerId: owner}
+	}
+	return nil
+}
+
+// CreateAccountClaim records that the member says the account is theirs,
+// for an admin to settle with ResolveAccountClaim.
+func CreateAccountClaim(guildID, username, claimantId string) (AccountClaim, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		return AccountClaim{}, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	owner, account, ok := accountOwner(participants, username)
+	if !ok {
+		return AccountClaim{}, ErrAccountNotTracked
+	}
+	if owner == claimantId {
+		return AccountClaim{}, ErrAccountAlreadyTracked
+	}
+
+	claims, err := getAccountClaims(guildID)
+	if err != nil {
+		return AccountClaim{}, fmt.Errorf("failed to fetch claims: %w", err)
+	}
+
+	accountKey := cases.Fold().String(account.Name)
+	if slices.ContainsFunc(claims, func(c AccountClaim) bool {
+		return c.AccountKey == accountKey && c.ClaimantId == claimantId
+	}) {
+		return AccountClaim{}, ErrClaimPending
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return AccountClaim{}, fmt.Errorf("failed to generate claim id: %w", err)
+	}
+
+	claim := AccountClaim{
+		ID:          hex.EncodeToString(id),
+		AccountKey:  accountKey,
+		AccountName: account.Name,
+		OwnerId:     owner,
+		ClaimantId:  claimantId,
+		CreatedAt:   time.Now(),
+	}
+
+	err = saveAccountClaims(guildID, append(claims, claim))
+	if err != nil {
+		return AccountClaim{}, fmt.Errorf("failed to save claims: %w", err)
+	}
+
+	return claim, nil
+}
+
+// CancelAccountClaim drops a claim without settling it, so the member can
+// claim the account again.
+func CancelAccountClaim(guildID, claimID string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	claims, err := getAccountClaims(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claims: %w", err)
+	}
+
+	claims = slices.DeleteFunc(claims, func(c AccountClaim) bool {
+		return c.ID == claimID
+	})
+	err = saveAccountClaims(guildID, claims)
+	if err != nil {
+		return fmt.Errorf("failed to save claims: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveAccountClaim settles a claim. Granting it moves the account, with
+// its event progress, to the claimant; otherwise it stays where it is.
+func ResolveAccountClaim(guildID, claimID string, grant bool) (AccountClaim, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	claims, err := getAccountClaims(guildID)
+	if err != nil {
+		return AccountClaim{}, fmt.Errorf("failed to fetch claims: %w", err)
+	}
+
+	index := slices.IndexFunc(claims, func(c AccountClaim) bool {
+		return c.ID == claimID
+	})
+	if index < 0 {
+		return AccountClaim{}, ErrClaimHandled
+	}
+	claim := claims[index]
+
+	if grant {
+		err = transferAccount(guildID, claim.AccountName, claim.OwnerId, claim.ClaimantId)
+		if err != nil {
+			return claim, fmt.Errorf("could not move the account: %w", err)
+		}
+	}
+
+	// Other claims on the account were about the old situation, they go too
+	claims = slices.DeleteFunc(claims, func(c AccountClaim) bool {
+		return c.ID == claimID || (grant && c.AccountKey == claim.AccountKey)
+	})
+	err = saveAccountClaims(guildID, claims)
+	if err != nil {
+		return claim, fmt.Errorf("failed to save claims: %w", err)
+	}
+
+	return claim, nil
+}
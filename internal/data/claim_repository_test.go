@@ -0,0 +1,117 @@
+package data
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"misclicked-events/internal/fakehiscore"
+)
+
+func TestUnverifiedAccountsDontBlockTracking(t *testing.T) {
+	useTestAssets(t)
+	srv := useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {
+				{At: 0, Skills: verificationSkillXP(100_000), Activities: zulrah(10)},
+				{At: fakehiscore.Duration(time.Minute), Skills: verificationSkillXP(100_150), Activities: zulrah(10)},
+			},
+		},
+	})
+
+	const guildID = "guild"
+	if err := SaveBotConfig(guildID, BotConfig{RequireVerification: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nobody has proven the account is theirs, so neither blocks the other
+	for _, discordId := range []string{"1", "2"} {
+		if err := TrackAccount(guildID, "Alpha", discordId); err != nil {
+			t.Fatalf("TrackAccount for %s: %v", discordId, err)
+		}
+	}
+
+	if _, err := StartVerification(guildID, "Alpha", "2"); err != nil {
+		t.Fatalf("StartVerification: %v", err)
+	}
+	srv.Advance(time.Minute)
+	verified, _, err := CheckVerification(guildID, "Alpha", "2")
+	if err != nil || !verified {
+		t.Fatalf("CheckVerification = %v, %v, want verified", verified, err)
+	}
+
+	// The member who verified it owns it, the other lost it
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := participants["1"]; ok {
+		t.Errorf("member 1 still tracks the account: %+v", participants["1"])
+	}
+
+	var owned *AccountOwnedError
+	if err := TrackAccount(guildID, "Alpha", "3"); !errors.As(err, &owned) || owned.OwnerId != "2" {
+		t.Errorf("TrackAccount after verification = %v, want owned by 2", err)
+	}
+}
+
+func TestFindDuplicateAccounts(t *testing.T) {
+	useTestAssets(t)
+
+	const guildID = "guild"
+	participants := map[string]Participant{
+		"1": {DiscordId: "1", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"alpha": {Name: "Alpha"},
+			"bravo": {Name: "Bravo"},
+		}},
+		"2": {DiscordId: "2", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"alpha": {Name: "alpha"},
+			"bravo": {Name: "Bravo", Unverified: true},
+		}},
+		"3": {DiscordId: "3", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"alpha":   {Name: "Alpha"},
+			"charlie": {Name: "Charlie"},
+		}},
+	}
+	if err := saveParticipantsData(guildID, participants); err != nil {
+		t.Fatal(err)
+	}
+
+	duplicates, err := FindDuplicateAccounts(guildID)
+	if err != nil {
+		t.Fatalf("FindDuplicateAccounts: %v", err)
+	}
+	if len(duplicates) != 1 || !slices.Equal(duplicates[0].OwnerIds, []string{"1", "2", "3"}) {
+		t.Errorf("duplicates = %+v, want Alpha for 1, 2 and 3", duplicates)
+	}
+}
+
+func TestCancelledClaimCanBeMadeAgain(t *testing.T) {
+	useTestAssets(t)
+
+	const guildID = "guild"
+	participants := map[string]Participant{
+		"1": {DiscordId: "1", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"alpha": {Name: "Alpha"},
+		}},
+	}
+	if err := saveParticipantsData(guildID, participants); err != nil {
+		t.Fatal(err)
+	}
+
+	claim, err := CreateAccountClaim(guildID, "Alpha", "2")
+	if err != nil {
+		t.Fatalf("CreateAccountClaim: %v", err)
+	}
+	if _, err := CreateAccountClaim(guildID, "Alpha", "2"); !errors.Is(err, ErrClaimPending) {
+		t.Fatalf("CreateAccountClaim again = %v, want pending", err)
+	}
+
+	if err := CancelAccountClaim(guildID, claim.ID); err != nil {
+		t.Fatalf("CancelAccountClaim: %v", err)
+	}
+	if _, err := CreateAccountClaim(guildID, "Alpha", "2"); err != nil {
+		t.Errorf("CreateAccountClaim after cancelling = %v", err)
+	}
+}
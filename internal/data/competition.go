@@ -4,14 +4,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 )
 
 type Competition struct {
 	CurrentBoss string `json:"currentBoss"`
-	Password    string `json:"password"`
+	// PasswordHash is the bcrypt hash of the event password, empty when the
+	// event was started without one.
+	PasswordHash string    `json:"passwordHash,omitempty"`
+	StartedAt    time.Time `json:"startedAt"`
+
+	// Deprecated: only read to migrate events started before passwords were hashed.
+	Password string `json:"password,omitempty"`
 }
 
-const competitionFilePath = "./assets/%s_competition.json"
+const competitionFilePath = "%s_competition.json"
 
 func GetCurrentBoss(guildID string) string {
 	competitionData, err := getCompetitionData(guildID)
@@ -23,7 +30,7 @@ func GetCurrentBoss(guildID string) string {
 }
 
 func getCompetitionData(guildID string) (*Competition, error) {
-	file, err := os.Open(fmt.Sprintf(competitionFilePath, guildID))
+	file, err := os.Open(assetPath(competitionFilePath, guildID))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -57,17 +64,17 @@ func getCompetitionData(guildID string) (*Competition, error) {
 }
 
 func clearCompetition(guildID string) {
-	saveCompetitionData(guildID, "", "")
+	saveCompetitionData(guildID, Competition{})
 }
 
-func saveCompetitionData(guildID string, currentBoss string, password string) error {
-	data, err := json.MarshalIndent(Competition{CurrentBoss: currentBoss, Password: password}, "", "  ") // Pretty-print
+func saveCompetitionData(guildID string, competition Competition) error {
+	data, err := json.MarshalIndent(competition, "", "  ") // Pretty-print
 	if err != nil {
 		return fmt.Errorf("failed to marshal persons: %w", err)
 	}
 
 	// Create or overwrite the file
-	file, err := os.Create(fmt.Sprintf(competitionFilePath, guildID))
+	file, err := os.Create(assetPath(competitionFilePath, guildID))
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -1,19 +1,60 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"misclicked-events/internal/constants"
 	"misclicked-events/internal/service"
 	"misclicked-events/internal/utils"
 	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrIncorrectPassword is returned when the event password doesn't match.
+	ErrIncorrectPassword = errors.New("incorrect event password")
+	ErrNoEventRunning    = errors.New("no event is currently running")
 )
 
+// StartCompetition starts an event for the activity. The password is
+// optional, ending an event that has one needs it unless done by an organizer.
 func StartCompetition(guildID string, bossId string, competitionPassword string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	passwordHash, err := hashCompetitionPassword(competitionPassword)
+	if err != nil {
+		return err
+	}
 
-	saveCompetitionData(guildID, bossId, competitionPassword)
+	competition := Competition{
+		CurrentBoss:  bossId,
+		PasswordHash: passwordHash,
+		StartedAt:    time.Now(),
+	}
+	saveCompetitionData(guildID, competition)
+
+	err = clearStandingsSnapshots(guildID)
+	if err != nil {
+		utils.LogError("error when clearing the previous standings", err)
+	}
 
 	lookupInitialKcForParticipantsAsync(guildID, bossId)
 
+	// The first snapshot is the starting line for KC gains and charts
+	err = RecordStandingsSnapshot(guildID)
+	if err != nil {
+		utils.LogError("error when recording the starting standings", err)
+	}
+
+	SendWebhookEvent(guildID, WebhookCompetitionStarted, CompetitionStartedEvent{
+		Activity:  bossId,
+		Threshold: constants.Activities[bossId].Threshold,
+		StartedAt: competition.StartedAt,
+	})
+
 	return nil
 }
 
@@ -35,23 +76,14 @@ func lookupInitialKcForParticipantsAsync(guildID, bossId string) {
 			go func(discordId, accountName string, account OSRSAccount) {
 				defer wg.Done() // Decrement the counter when the goroutine completes
 
-				// Fetch hiscores for the account
-				_, activities, err := service.FetchHiscore(accountName)
+				// Fetch the starting KC for the account, when the hiscores
+				// don't list the boss the first successful update sets it
+				kc, err := fetchKc(accountName, bossId)
 				if err != nil {
-					fmt.Printf("Error fetching hiscore for account %s: %v\n", accountName, err)
+					fmt.Printf("Error fetching KC for account %s: %v\n", accountName, err)
 					return
 				}
 
-				kc := 0
-				for _, act := range constants.Activities[bossId].BossNames {
-					activity, exists := service.FindActivity(activities, act)
-					if !exists {
-						fmt.Printf("No activity found for boss %s for account %s\n", bossId, accountName)
-						continue
-					}
-					kc += max(0, activity.Score)
-				}
-
 				// Add the initial activity to the account
 				mu.Lock() // Lock the map for concurrent write
 				if account.Activities == nil {
@@ -61,6 +93,8 @@ func lookupInitialKcForParticipantsAsync(guildID, bossId string) {
 					Name:          bossId,
 					StartAmount:   kc,
 					CurrentAmount: kc,
+					UpdatedAt:     time.Now(),
+					ChangedAt:     time.Now(),
 				}
 				participant.LinkedOSRSAccounts[accountName] = account
 				participants[discordId] = participant
@@ -78,34 +112,139 @@ func lookupInitialKcForParticipantsAsync(guildID, bossId string) {
 	}
 }
 
-func EndCompetition(guildID string, competitionPassword string) error {
+// EndCompetition does a last KC update, hands out the points and clears the
+// competition. It returns the final standings, and the report of the last
+// update so it can be acted on. While KC changes wait for review, including
+// ones held by the last update, it returns a *PendingReviewsError instead, and
+// a *SchemaError while the hiscores don't list the activity.
+func EndCompetition(guildID string) (CompetitionResult, KCUpdateReport, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	var result CompetitionResult
+	var report KCUpdateReport
 
 	competition, err := getCompetitionData(guildID)
 	if err != nil {
-		return err
+		return result, report, err
 	}
 
-	if len(competition.CurrentBoss) < 1 {
-		return fmt.Errorf("no event is currently running")
+	if competition == nil || len(competition.CurrentBoss) < 1 {
+		return result, report, ErrNoEventRunning
 	}
 
-	if competition.Password != competitionPassword {
-		return fmt.Errorf("incorrect event password")
+	// Waiting out an outage could take hours, the event ends on the last
+	// known KC instead and the report says so
+	report, err = updateAccountsKC(guildID)
+	if errors.Is(err, service.ErrHiscoresUnavailable) {
+		utils.LogError("hiscores are unavailable, ending the event on the last known KC", err)
+	} else if err != nil {
+		utils.LogError("error when updating accounts", err)
+		return result, report, fmt.Errorf("error when updating accounts")
+	}
+
+	// The last known KC may be hours old, the final standings can't rely on it
+	if report.Blocked {
+		return result, report, &SchemaError{
+			Activity: competition.CurrentBoss,
+			Missing:  missingForActivity(competition.CurrentBoss, report.MissingBossNames),
+		}
 	}
 
-	err = UpdateAccountsKC(guildID)
+	// Points handed out now would ignore whatever the admins decide
+	pending, err := pendingKCReviews(guildID, competition.CurrentBoss)
 	if err != nil {
-		utils.LogError("error when updating accounts", err)
-		return fmt.Errorf("error when updating accounts")
+		utils.LogError("error when fetching KC reviews", err)
+		return result, report, fmt.Errorf("error when fetching KC reviews")
+	}
+	if len(pending) > 0 {
+		return result, report, &PendingReviewsError{Reviews: pending}
+	}
+
+	// The final standings are the last point of the event's KC chart
+	err = RecordStandingsSnapshot(guildID)
+	if err != nil {
+		utils.LogError("error when recording the final standings", err)
+	}
+
+	// Take the final standings before the points change anything
+	result, err = competitionResult(guildID, *competition)
+	if err != nil {
+		utils.LogError("error when collecting the final standings", err)
+		return result, report, fmt.Errorf("error when collecting the final standings")
 	}
 
-	err = CalculatePointsForParticipants(guildID)
+	err = calculatePointsForParticipants(guildID)
 	if err != nil {
 		utils.LogError("error when calculating points", err)
-		return fmt.Errorf("error when calculating points")
+		return result, report, fmt.Errorf("error when calculating points")
+	}
+
+	err = appendCompetitionResult(guildID, result)
+	if err != nil {
+		// The points are handed out already, losing the history isn't worth failing over
+		utils.LogError("error when saving the competition history", err)
 	}
 
 	clearCompetition(guildID)
 
+	SendWebhookEvent(guildID, WebhookCompetitionEnded, result)
+	sendPointsAwarded(guildID, result)
+
+	return result, report, nil
+}
+
+// CheckCompetitionPassword returns ErrIncorrectPassword when the running event
+// has a password and it isn't the given one.
+func CheckCompetitionPassword(guildID, password string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	competition, err := getCompetitionData(guildID)
+	if err != nil {
+		return err
+	}
+	if competition == nil {
+		return nil
+	}
+
+	// Events started before passwords were hashed get hashed the first time
+	// their password is needed
+	if competition.Password != "" {
+		competition.PasswordHash, err = hashCompetitionPassword(competition.Password)
+		if err != nil {
+			return err
+		}
+		competition.Password = ""
+
+		err = saveCompetitionData(guildID, *competition)
+		if err != nil {
+			return fmt.Errorf("failed to save the hashed password: %w", err)
+		}
+	}
+
+	if competition.PasswordHash == "" {
+		return nil
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(competition.PasswordHash), []byte(password))
+	if err != nil {
+		return ErrIncorrectPassword
+	}
+
 	return nil
 }
+
+// hashCompetitionPassword hashes the password to store it, events without a
+// password keep an empty hash.
+func hashCompetitionPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash the event password: %w", err)
+	}
+	return string(hash), nil
+}
@@ -0,0 +1,37 @@
+package data
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckCompetitionPasswordHashesLegacyPasswords(t *testing.T) {
+	useTestAssets(t)
+
+	const guildID = "guild"
+	if err := saveCompetitionData(guildID, Competition{CurrentBoss: "Zulrah", Password: "hunter2"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reading the event leaves the file alone
+	if boss := GetCurrentBoss(guildID); boss != "Zulrah" {
+		t.Fatalf("GetCurrentBoss = %q, want Zulrah", boss)
+	}
+	competition, err := getCompetitionData(guildID)
+	if err != nil || competition.Password != "hunter2" {
+		t.Fatalf("getCompetitionData = %+v, %v, want the legacy password untouched", competition, err)
+	}
+
+	if err := CheckCompetitionPassword(guildID, "wrong"); !errors.Is(err, ErrIncorrectPassword) {
+		t.Errorf("CheckCompetitionPassword with the wrong password = %v, want ErrIncorrectPassword", err)
+	}
+
+	competition, err = getCompetitionData(guildID)
+	if err != nil || competition.Password != "" || competition.PasswordHash == "" {
+		t.Fatalf("getCompetitionData = %+v, %v, want only the hash stored", competition, err)
+	}
+
+	if err := CheckCompetitionPassword(guildID, "hunter2"); err != nil {
+		t.Errorf("CheckCompetitionPassword with the right password = %v", err)
+	}
+}
@@ -2,19 +2,43 @@ package data
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 )
 
+// ErrNoBotConfig is returned for guilds that haven't set up the channels yet.
+var ErrNoBotConfig = errors.New("the channels haven't been set up")
+
 type BotConfig struct {
 	CategoryChannelID string `json:"categoryChannelId"`
 	HiscoreChannelID  string `json:"hiscoreChannelId"`
-	HiscoreMessageID  string `json:"hiscoreMessageId"`
 	RankingChannelID  string `json:"rankingChannelId"`
-	RankingMessageID  string `json:"rankingMessageId"`
+	AdminChannelID    string `json:"adminChannelId,omitempty"`
+	// The podium is announced here when an event ends, mentioning the role if set.
+	AnnouncementChannelID string `json:"announcementChannelId,omitempty"`
+	AnnouncementRoleID    string `json:"announcementRoleId,omitempty"`
+	// The language the bot speaks in the guild, English when empty.
+	Locale string `json:"locale,omitempty"`
+	// RequireVerification keeps the KC of newly tracked accounts off the
+	// leaderboard until their owner passes a challenge.
+	RequireVerification bool `json:"requireVerification,omitempty"`
+	// Competition events are posted to these URLs.
+	Webhooks []WebhookConfig `json:"webhooks,omitempty"`
+	// Members with one of these roles start and end events, without the
+	// event password.
+	OrganizerRoleIDs []string `json:"organizerRoleIds,omitempty"`
+	// The leaderboards are split over as many messages as they need, in order.
+	HiscoreMessageIDs []string `json:"hiscoreMessageIds,omitempty"`
+	RankingMessageIDs []string `json:"rankingMessageIds,omitempty"`
+
+	// Deprecated: only read to migrate configs from before the leaderboards were split.
+	HiscoreMessageID string `json:"hiscoreMessageId,omitempty"`
+	// Deprecated: only read to migrate configs from before the leaderboards were split.
+	RankingMessageID string `json:"rankingMessageId,omitempty"`
 }
 
-const configPath = "./assets/%s_config.json"
+const configPath = "%s_config.json"
 
 func SaveBotConfig(guildID string, botConfig BotConfig) error {
 	data, err := json.MarshalIndent(botConfig, "", " ")
@@ -22,10 +46,11 @@ func SaveBotConfig(guildID string, botConfig BotConfig) error {
 		return fmt.Errorf("failed to marshal persons: %w", err)
 	}
 
-	file, err := os.Create(fmt.Sprintf(configPath, guildID))
+	file, err := os.Create(assetPath(configPath, guildID))
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
+	defer file.Close()
 
 	_, err = file.Write(data)
 	if err != nil {
@@ -36,10 +61,14 @@ func SaveBotConfig(guildID string, botConfig BotConfig) error {
 }
 
 func GetBotConfig(guildID string) (*BotConfig, error) {
-	file, err := os.Open(fmt.Sprintf(configPath, guildID))
+	file, err := os.Open(assetPath(configPath, guildID))
+	if os.IsNotExist(err) {
+		return nil, ErrNoBotConfig
+	}
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
 	stat, err := file.Stat()
 	if err != nil {
@@ -62,10 +91,22 @@ func GetBotConfig(guildID string) (*BotConfig, error) {
 		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
+	if len(config.HiscoreMessageIDs) == 0 && config.HiscoreMessageID != "" {
+		config.HiscoreMessageIDs = []string{config.HiscoreMessageID}
+	}
+	if len(config.RankingMessageIDs) == 0 && config.RankingMessageID != "" {
+		config.RankingMessageIDs = []string{config.RankingMessageID}
+	}
+	config.HiscoreMessageID = ""
+	config.RankingMessageID = ""
+
 	return &config, nil
 }
 
 func UpdateChannelIDs(guildID string, newChannels BotConfig) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
 	config, err := GetBotConfig(guildID)
 	if err != nil {
 		return fmt.Errorf("failed to get bot config: %w", err)
@@ -75,30 +116,50 @@ func UpdateChannelIDs(guildID string, newChannels BotConfig) error {
 	config.CategoryChannelID = newChannels.CategoryChannelID
 	config.HiscoreChannelID = newChannels.HiscoreChannelID
 	config.RankingChannelID = newChannels.RankingChannelID
-	config.HiscoreMessageID = ""
-	config.RankingMessageID = ""
+	config.HiscoreMessageIDs = nil
+	config.RankingMessageIDs = nil
+
+	return SaveBotConfig(guildID, *config)
+}
+
+func UpdateHiscoreMessageIDs(guildID string, hiscoreMessageIDs []string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	config.HiscoreMessageIDs = hiscoreMessageIDs
 
 	return SaveBotConfig(guildID, *config)
 }
 
-func UpdateHiscoreMessageID(guildID, hiscoreMessageID string) error {
+func UpdateLocale(guildID string, locale string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
 	config, err := GetBotConfig(guildID)
 	if err != nil {
 		return fmt.Errorf("failed to get bot config: %w", err)
 	}
 
-	config.HiscoreMessageID = hiscoreMessageID
+	config.Locale = locale
 
 	return SaveBotConfig(guildID, *config)
 }
 
-func UpdateRankingMessageID(guildID, rankingMessageID string) error {
+func UpdateRankingMessageIDs(guildID string, rankingMessageIDs []string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
 	config, err := GetBotConfig(guildID)
 	if err != nil {
 		return fmt.Errorf("failed to get bot config: %w", err)
 	}
 
-	config.RankingMessageID = rankingMessageID
+	config.RankingMessageIDs = rankingMessageIDs
 
 	return SaveBotConfig(guildID, *config)
 }
@@ -4,16 +4,49 @@ import (
 	"misclicked-events/internal/utils"
 )
 
-func UpdateConfig(guildID, rankingChannelID, hiscoreChannelID, categoryChannelID string) error {
-	botConfig := BotConfig{
-		RankingChannelID:  rankingChannelID,
-		HiscoreChannelID:  hiscoreChannelID,
-		CategoryChannelID: categoryChannelID,
+// ChannelSettings are the channels and role picked with /setup-channels.
+type ChannelSettings struct {
+	RankingChannelID      string
+	HiscoreChannelID      string
+	CategoryChannelID     string
+	AdminChannelID        string
+	AnnouncementChannelID string
+	AnnouncementRoleID    string
+}
+
+func UpdateConfig(guildID string, settings ChannelSettings) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	// Keep the settings that aren't part of the channel setup
+	botConfig := BotConfig{}
+	if existing, err := GetBotConfig(guildID); err == nil {
+		botConfig = *existing
 	}
 
+	// The old messages live in the old channels, new ones get posted on the next update
+	botConfig.RankingChannelID = settings.RankingChannelID
+	botConfig.HiscoreChannelID = settings.HiscoreChannelID
+	botConfig.CategoryChannelID = settings.CategoryChannelID
+	botConfig.AdminChannelID = settings.AdminChannelID
+	botConfig.AnnouncementChannelID = settings.AnnouncementChannelID
+	botConfig.AnnouncementRoleID = settings.AnnouncementRoleID
+	botConfig.HiscoreMessageIDs = nil
+	botConfig.RankingMessageIDs = nil
+
 	err := SaveBotConfig(guildID, botConfig)
 	if err != nil {
 		utils.LogError("Something went wrong while updating config", err)
 	}
 	return err
 }
+
+// GetGuildLocale returns the locale the guild picked, or an empty string when
+// it didn't pick one.
+func GetGuildLocale(guildID string) string {
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return ""
+	}
+	return config.Locale
+}
@@ -0,0 +1,323 @@
+package data
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"misclicked-events/internal/constants"
+	"misclicked-events/internal/fakehiscore"
+	"misclicked-events/internal/service"
+)
+
+// useTestAssets keeps the test's data files in an empty directory of its own.
+// The directory is package state, so tests using it can't run in parallel.
+func useTestAssets(t *testing.T) {
+	t.Helper()
+
+	previous := assetsDir
+	assetsDir = t.TempDir()
+	t.Cleanup(func() { assetsDir = previous })
+}
+
+// useFakeHiscores points the hiscore client at a fake server playing fixture.
+// The hiscore health is shared by every test, it starts and ends clean.
+func useFakeHiscores(t *testing.T, fixture fakehiscore.Fixture) *fakehiscore.Server {
+	t.Helper()
+
+	srv := fakehiscore.New(fixture)
+	ts := httptest.NewServer(srv)
+	service.SetBaseURL(ts.URL)
+	service.ResetHealth()
+	t.Cleanup(func() {
+		service.SetBaseURL("")
+		service.ResetHealth()
+		ts.Close()
+	})
+
+	return srv
+}
+
+func zulrah(kc int) map[string]int {
+	return map[string]int{"Zulrah": kc}
+}
+
+func TestCompetitionEndToEnd(t *testing.T) {
+	useTestAssets(t)
+	srv := useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {
+				{At: 0, Activities: zulrah(100)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(130)},
+			},
+			"Bravo": {
+				{At: 0, Activities: zulrah(50)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(80)},
+			},
+			"Charlie": {
+				{At: 0, Activities: zulrah(10)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(20)},
+			},
+			"Delta": {
+				{At: 0, Activities: zulrah(0)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(40)},
+			},
+		},
+	})
+
+	const guildID = "guild"
+	accounts := map[string]string{
+		"Alpha":   "1",
+		"Bravo":   "2",
+		"Charlie": "3",
+		"Delta":   "4",
+	}
+	for username, discordId := range accounts {
+		if err := TrackAccount(guildID, username, discordId); err != nil {
+			t.Fatalf("TrackAccount(%s): %v", username, err)
+		}
+	}
+
+	if err := StartCompetition(guildID, "Zulrah", ""); err != nil {
+		t.Fatalf("StartCompetition: %v", err)
+	}
+
+	srv.Advance(time.Hour)
+
+	report, err := UpdateAccountsKC(guildID)
+	if err != nil {
+		t.Fatalf("UpdateAccountsKC: %v", err)
+	}
+	if len(report.NewReviews) > 0 || report.Blocked || report.Validated != len(accounts) {
+		t.Fatalf("unexpected update report: %+v", report)
+	}
+
+	// Alpha and Bravo tie, Charlie stays below the threshold of 25
+	leaderboard, err := GetParticipantsByActivityKC(guildID)
+	if err != nil {
+		t.Fatalf("GetParticipantsByActivityKC: %v", err)
+	}
+	wantKC := map[string]int{"1": 30, "2": 30, "3": 10, "4": 40}
+	if len(leaderboard) != len(wantKC) {
+		t.Fatalf("leaderboard has %d participants, want %d", len(leaderboard), len(wantKC))
+	}
+	for i, participant := range leaderboard {
+		if participant.TotalKC != wantKC[participant.DiscordId] {
+			t.Errorf("participant %s has %d KC, want %d", participant.DiscordId, participant.TotalKC, wantKC[participant.DiscordId])
+		}
+		if i > 0 && participant.TotalKC > leaderboard[i-1].TotalKC {
+			t.Errorf("leaderboard isn't sorted by KC: %+v", leaderboard)
+		}
+	}
+
+	result, _, err := EndCompetition(guildID)
+	if err != nil {
+		t.Fatalf("EndCompetition: %v", err)
+	}
+
+	wantStandings := map[string]Standing{
+		"4": {Rank: 1, Points: 12, TotalKC: 40},
+		"1": {Rank: 2, Points: 9, TotalKC: 30},
+		"2": {Rank: 2, Points: 9, TotalKC: 30},
+		"3": {Rank: 0, Points: 0, TotalKC: 10},
+	}
+	if len(result.Standings) != len(wantStandings) {
+		t.Fatalf("result has %d standings, want %d", len(result.Standings), len(wantStandings))
+	}
+	for _, standing := range result.Standings {
+		want := wantStandings[standing.DiscordId]
+		if standing.Rank != want.Rank || standing.Points != want.Points || standing.TotalKC != want.TotalKC {
+			t.Errorf("standing of %s is rank %d, %d points, %d KC, want rank %d, %d points, %d KC",
+				standing.DiscordId, standing.Rank, standing.Points, standing.TotalKC, want.Rank, want.Points, want.TotalKC)
+		}
+	}
+
+	if boss := GetCurrentBoss(guildID); boss != "" {
+		t.Errorf("event still running for %q after it ended", boss)
+	}
+
+	ranking, err := GetParticipantsInOrder(guildID)
+	if err != nil {
+		t.Fatalf("GetParticipantsInOrder: %v", err)
+	}
+	for i, participant := range ranking {
+		if want := wantStandings[participant.DiscordId].Points; participant.Points != want {
+			t.Errorf("participant %s has %d points, want %d", participant.DiscordId, participant.Points, want)
+		}
+		if i > 0 && participant.Points > ranking[i-1].Points {
+			t.Errorf("ranking isn't sorted by points")
+		}
+	}
+
+	history, err := GetCompetitionHistory(guildID)
+	if err != nil {
+		t.Fatalf("GetCompetitionHistory: %v", err)
+	}
+	if len(history) != 1 || history[0].Activity != "Zulrah" {
+		t.Errorf("history = %+v, want the Zulrah event", history)
+	}
+}
+
+func TestEndCompetitionWaitsForReviews(t *testing.T) {
+	useTestAssets(t)
+	srv := useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {
+				{At: 0, Activities: zulrah(100)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(500)},
+			},
+		},
+	})
+
+	const guildID = "guild"
+	if err := SaveBotConfig(guildID, BotConfig{AdminChannelID: "admins"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := TrackAccount(guildID, "Alpha", "1"); err != nil {
+		t.Fatalf("TrackAccount: %v", err)
+	}
+	if err := StartCompetition(guildID, "Zulrah", ""); err != nil {
+		t.Fatalf("StartCompetition: %v", err)
+	}
+
+	srv.Advance(time.Hour)
+
+	// The final update holds the jump of 400 KC, which has to be settled first
+	_, report, err := EndCompetition(guildID)
+	var pending *PendingReviewsError
+	if !errors.As(err, &pending) {
+		t.Fatalf("EndCompetition error = %v, want pending reviews", err)
+	}
+	if len(pending.Reviews) != 1 || len(report.NewReviews) != 1 {
+		t.Fatalf("got %d pending and %d new reviews, want 1 each", len(pending.Reviews), len(report.NewReviews))
+	}
+	if GetCurrentBoss(guildID) != "Zulrah" {
+		t.Fatal("event ended with a review pending")
+	}
+
+	if _, err := ResolveKCReview(guildID, pending.Reviews[0].ID, true); err != nil {
+		t.Fatalf("ResolveKCReview: %v", err)
+	}
+
+	result, _, err := EndCompetition(guildID)
+	if err != nil {
+		t.Fatalf("EndCompetition: %v", err)
+	}
+	if len(result.Standings) != 1 || result.Standings[0].TotalKC != 400 || result.Standings[0].Points != 12 {
+		t.Errorf("standings = %+v, want the approved 400 KC for 12 points", result.Standings)
+	}
+}
+
+func TestChangesCountWithoutAnAdminChannel(t *testing.T) {
+	useTestAssets(t)
+	srv := useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {
+				{At: 0, Activities: zulrah(100)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(500)},
+			},
+		},
+	})
+
+	const guildID = "guild"
+	if err := SaveBotConfig(guildID, BotConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := TrackAccount(guildID, "Alpha", "1"); err != nil {
+		t.Fatalf("TrackAccount: %v", err)
+	}
+	if err := StartCompetition(guildID, "Zulrah", ""); err != nil {
+		t.Fatalf("StartCompetition: %v", err)
+	}
+
+	srv.Advance(time.Hour)
+
+	// Nobody would get to see a review, so the event can't get stuck on one
+	result, report, err := EndCompetition(guildID)
+	if err != nil {
+		t.Fatalf("EndCompetition: %v", err)
+	}
+	if len(report.NewReviews) != 0 || len(result.Standings) != 1 || result.Standings[0].TotalKC != 400 {
+		t.Errorf("report = %+v, standings = %+v, want the 400 KC counted", report, result.Standings)
+	}
+}
+
+func TestEndCompetitionRefusesWhileBlocked(t *testing.T) {
+	useTestAssets(t)
+	useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {{At: 0, Activities: zulrah(100)}},
+		},
+	})
+
+	const guildID = "guild"
+	if err := TrackAccount(guildID, "Alpha", "1"); err != nil {
+		t.Fatalf("TrackAccount: %v", err)
+	}
+	if err := StartCompetition(guildID, "Zulrah", ""); err != nil {
+		t.Fatalf("StartCompetition: %v", err)
+	}
+
+	// Pretend the hiscores renamed one of the event's bosses
+	original := constants.Activities["Zulrah"]
+	renamed := original
+	renamed.BossNames = append(slices.Clone(original.BossNames), "Zulrah (renamed)")
+	constants.Activities["Zulrah"] = renamed
+	t.Cleanup(func() { constants.Activities["Zulrah"] = original })
+
+	_, report, err := EndCompetition(guildID)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("EndCompetition error = %v, want a schema error", err)
+	}
+	if !report.Blocked || !slices.Equal(schemaErr.Missing, []string{"Zulrah (renamed)"}) {
+		t.Errorf("report = %+v, error = %v", report, schemaErr)
+	}
+	if GetCurrentBoss(guildID) != "Zulrah" {
+		t.Error("event ended while updates were blocked")
+	}
+}
+
+func TestEndCompetitionDuringOutage(t *testing.T) {
+	useTestAssets(t)
+	players := map[string][]fakehiscore.Step{}
+	for _, name := range []string{"Alpha", "Bravo", "Charlie", "Delta", "Echo"} {
+		players[name] = []fakehiscore.Step{
+			{At: 0, Activities: zulrah(100)},
+			{At: fakehiscore.Duration(time.Hour), Activities: zulrah(130)},
+		}
+	}
+	srv := useFakeHiscores(t, fakehiscore.Fixture{Players: players})
+
+	const guildID = "guild"
+	for name := range players {
+		if err := TrackAccount(guildID, name, name); err != nil {
+			t.Fatalf("TrackAccount(%s): %v", name, err)
+		}
+	}
+	if err := StartCompetition(guildID, "Zulrah", ""); err != nil {
+		t.Fatalf("StartCompetition: %v", err)
+	}
+
+	srv.Advance(time.Hour)
+	srv.FailNext("", http.StatusInternalServerError, 100)
+
+	result, report, err := EndCompetition(guildID)
+	if err != nil {
+		t.Fatalf("EndCompetition: %v", err)
+	}
+	if !report.Unavailable {
+		t.Errorf("report = %+v, want the hiscores unavailable", report)
+	}
+	for _, standing := range result.Standings {
+		if standing.TotalKC != 0 {
+			t.Errorf("%s ended with %d KC, want the last known 0", standing.DiscordId, standing.TotalKC)
+		}
+	}
+	if GetCurrentBoss(guildID) != "" {
+		t.Error("event still running after ending on the last known KC")
+	}
+}
@@ -0,0 +1,219 @@
+package data
+
+import (
+	"fmt"
+	"misclicked-events/internal/constants"
+	"sort"
+	"time"
+)
+
+// EventExport is everything there is to know about the results of an event,
+// for the staff's own records.
+type EventExport struct {
+	Activity  string `json:"activity"`
+	Threshold int    `json:"threshold"`
+	// Ongoing is set for the running event, its points aren't handed out yet.
+	Ongoing      bool                `json:"ongoing"`
+	StartedAt    time.Time           `json:"startedAt"`
+	EndedAt      *time.Time          `json:"endedAt,omitempty"`
+	Participants []ExportParticipant `json:"participants"`
+	// Ranking is the overall ranking as it is now.
+	Ranking []ExportRanking `json:"ranking"`
+}
+
+// ExportParticipant is a participant of the event. Rank is 0 below the
+// threshold. Points are what the rank earns, or would earn when the event
+// ends now.
+type ExportParticipant struct {
+	DiscordId string          `json:"discordId"`
+	Name      string          `json:"name,omitempty"`
+	Rank      int             `json:"rank"`
+	TotalKC   int             `json:"totalKc"`
+	Points    int             `json:"points"`
+	Accounts  []ExportAccount `json:"accounts"`
+}
+
+// ExportAccount is an account's KC in the event. StartKC and CurrentKC are
+// missing for events that ended before they were recorded.
+type ExportAccount struct {
+	Name      string `json:"name"`
+	StartKC   *int   `json:"startKc,omitempty"`
+	CurrentKC *int   `json:"currentKc,omitempty"`
+	KC        int    `json:"kc"`
+	// Unverified accounts don't count towards the participant's total.
+	Unverified bool `json:"unverified,omitempty"`
+}
+
+type ExportRanking struct {
+	DiscordId string `json:"discordId"`
+	Name      string `json:"name,omitempty"`
+	Rank      int    `json:"rank"`
+	Points    int    `json:"points"`
+}
+
+// ExportCurrentEvent collects the standings of the running event, with every
+// account of every participant, including those without KC yet.
+func ExportCurrentEvent(guildID string) (EventExport, error) {
+	competition, err := getCompetitionData(guildID)
+	if err != nil {
+		return EventExport{}, err
+	}
+	if competition == nil || competition.CurrentBoss == "" {
+		return EventExport{}, fmt.Errorf("no event found")
+	}
+
+	activityName := competition.CurrentBoss
+	export := EventExport{
+		Activity:  activityName,
+		Threshold: constants.Activities[activityName].Threshold,
+		Ongoing:   true,
+		StartedAt: competition.StartedAt,
+	}
+
+	ranked, err := GetParticipantsByActivityKCThreshold(guildID)
+	if err != nil {
+		return EventExport{}, fmt.Errorf("failed to get participants above the threshold: %w", err)
+	}
+
+	// Equal KC shares a rank, like when the points are handed out
+	ranks := map[string]int{}
+	rank := 0
+	previousKC := -1
+	for i, participant := range ranked {
+		if participant.TotalKC != previousKC {
+			rank = i + 1
+		}
+		previousKC = participant.TotalKC
+		ranks[participant.DiscordId] = rank
+	}
+
+	participants, err := GetParticipantsInOrder(guildID)
+	if err != nil {
+		return EventExport{}, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	for _, participant := range participants {
+		exported := ExportParticipant{
+			DiscordId: participant.DiscordId,
+			Rank:      ranks[participant.DiscordId],
+		}
+		if exported.Rank > 0 {
+			exported.Points = PointsForRank(exported.Rank)
+		}
+
+		for _, account := range participant.LinkedOSRSAccounts {
+			activity, exists := account.Activities[activityName]
+			if !exists {
+				continue
+			}
+			// Unverified accounts are listed, but their KC doesn't count
+			if !account.Unverified {
+				exported.TotalKC += activity.KC()
+			}
+			exported.Accounts = append(exported.Accounts, ExportAccount{
+				Name:       account.Name,
+				StartKC:    &activity.StartAmount,
+				CurrentKC:  &activity.CurrentAmount,
+				KC:         activity.KC(),
+				Unverified: account.Unverified,
+			})
+		}
+		if len(exported.Accounts) == 0 {
+			continue
+		}
+		sortExportAccounts(exported.Accounts)
+
+		export.Participants = append(export.Participants, exported)
+	}
+	sortExportParticipants(export.Participants)
+
+	export.Ranking = exportRanking(participants)
+	return export, nil
+}
+
+// ExportPastEvent collects the final standings of an event from the history,
+// index 0 being the first event that ended.
+func ExportPastEvent(guildID string, index int) (EventExport, error) {
+	history, err := GetCompetitionHistory(guildID)
+	if err != nil {
+		return EventExport{}, err
+	}
+	if index < 0 || index >= len(history) {
+		return EventExport{}, fmt.Errorf("event not found")
+	}
+
+	result := history[index]
+	export := EventExport{
+		Activity:  result.Activity,
+		Threshold: constants.Activities[result.Activity].Threshold,
+		StartedAt: result.StartedAt,
+		EndedAt:   &result.EndedAt,
+	}
+
+	for _, standing := range result.Standings {
+		exported := ExportParticipant{
+			DiscordId: standing.DiscordId,
+			Rank:      standing.Rank,
+			TotalKC:   standing.TotalKC,
+			Points:    standing.Points,
+		}
+		for _, account := range standing.Accounts {
+			exportedAccount := ExportAccount{Name: account.Name, KC: account.KC}
+			if account.EndKC > 0 {
+				exportedAccount.StartKC = &account.StartKC
+				exportedAccount.CurrentKC = &account.EndKC
+			}
+			exported.Accounts = append(exported.Accounts, exportedAccount)
+		}
+		export.Participants = append(export.Participants, exported)
+	}
+
+	participants, err := GetParticipantsInOrder(guildID)
+	if err != nil {
+		return EventExport{}, fmt.Errorf("failed to get participants: %w", err)
+	}
+	export.Ranking = exportRanking(participants)
+
+	return export, nil
+}
+
+// exportRanking ranks everyone with points, participants sorted by points.
+func exportRanking(participants []Participant) []ExportRanking {
+	var ranking []ExportRanking
+	rank := 0
+	previousPoints := -1
+	for i, participant := range participants {
+		if participant.Points == 0 {
+			continue
+		}
+		if participant.Points != previousPoints {
+			rank = i + 1
+		}
+		previousPoints = participant.Points
+
+		ranking = append(ranking, ExportRanking{
+			DiscordId: participant.DiscordId,
+			Rank:      rank,
+			Points:    participant.Points,
+		})
+	}
+	return ranking
+}
+
+func sortExportParticipants(participants []ExportParticipant) {
+	sort.Slice(participants, func(i, j int) bool {
+		if participants[i].TotalKC != participants[j].TotalKC {
+			return participants[i].TotalKC > participants[j].TotalKC
+		}
+		return participants[i].DiscordId < participants[j].DiscordId
+	})
+}
+
+func sortExportAccounts(accounts []ExportAccount) {
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].KC != accounts[j].KC {
+			return accounts[i].KC > accounts[j].KC
+		}
+		return accounts[i].Name < accounts[j].Name
+	})
+}
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// CompetitionResult is the final outcome of an event that has ended.
+type CompetitionResult struct {
+	Activity  string     `json:"activity"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   time.Time  `json:"endedAt"`
+	Standings []Standing `json:"standings"`
+}
+
+// Standing is where a participant finished in an event. Participants below
+// the threshold have no rank and earn no points.
+type Standing struct {
+	DiscordId string            `json:"discordId"`
+	Rank      int               `json:"rank,omitempty"`
+	TotalKC   int               `json:"totalKc"`
+	Points    int               `json:"points"`
+	Accounts  []StandingAccount `json:"accounts"`
+}
+
+type StandingAccount struct {
+	Name string `json:"name"`
+	KC   int    `json:"kc"`
+	// StartKC and EndKC are the account's hiscore KC when the event started and
+	// ended. Both are 0 for events that ended before they were recorded.
+	StartKC int `json:"startKc,omitempty"`
+	EndKC   int `json:"endKc,omitempty"`
+}
+
+const historyFilePath = "%s_history.json"
+
+// GetCompetitionHistory returns every ended event of the guild, oldest first.
+func GetCompetitionHistory(guildID string) ([]CompetitionResult, error) {
+	data, err := os.ReadFile(assetPath(historyFilePath, guildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var history []CompetitionResult
+	err = json.Unmarshal(data, &history)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return history, nil
+}
+
+func saveCompetitionHistory(guildID string, history []CompetitionResult) error {
+	data, err := json.MarshalIndent(history, "", "  ") // Pretty-print
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	err = os.WriteFile(assetPath(historyFilePath, guildID), data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package data
+
+import (
+	"fmt"
+	"misclicked-events/internal/constants"
+	"sort"
+	"time"
+)
+
+// competitionResult collects the standings of the running competition.
+func competitionResult(guildID string, competition Competition) (CompetitionResult, error) {
+	participantKC, err := GetParticipantsByActivityKC(guildID)
+	if err != nil {
+		return CompetitionResult{}, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	threshold := constants.Activities[competition.CurrentBoss].Threshold
+
+	result := CompetitionResult{
+		Activity:  competition.CurrentBoss,
+		StartedAt: competition.StartedAt,
+		EndedAt:   time.Now(),
+	}
+
+	// Same ranking as the points: equal KC shares a rank
+	rank := 0
+	previousKC := -1
+	for i, participant := range participantKC {
+		standing := Standing{
+			DiscordId: participant.DiscordId,
+			TotalKC:   participant.TotalKC,
+		}
+
+		if participant.TotalKC >= threshold {
+			if participant.TotalKC != previousKC {
+				rank = i + 1
+			}
+			previousKC = participant.TotalKC
+
+			standing.Rank = rank
+			standing.Points = PointsForRank(rank)
+		}
+
+		for _, account := range participant.AccountKCs {
+			standing.Accounts = append(standing.Accounts, StandingAccount{
+				Name:    account.AccountName,
+				KC:      account.TotalKC,
+				StartKC: account.StartKC,
+				EndKC:   account.CurrentKC,
+			})
+		}
+
+		result.Standings = append(result.Standings, standing)
+	}
+
+	return result, nil
+}
+
+func appendCompetitionResult(guildID string, result CompetitionResult) error {
+	history, err := GetCompetitionHistory(guildID)
+	if err != nil {
+		return err
+	}
+
+	return saveCompetitionHistory(guildID, append(history, result))
+}
+
+// MemberStats is a member's record over every event they took part in.
+type MemberStats struct {
+	DiscordId   string
+	Points      int
+	OverallRank int // 0 without any points
+	Wins        int
+	Podiums     int
+	// Events holds the member's placements, newest first.
+	Events []EventPlacement
+	// BestPerActivity holds the best result per activity, sorted by activity.
+	BestPerActivity []ActivityBest
+	// Accounts holds the KC each account contributed over all events, highest first.
+	Accounts []AccountKC
+}
+
+type EventPlacement struct {
+	Activity     string
+	EndedAt      time.Time
+	Rank         int // 0 when below the threshold
+	TotalKC      int
+	Points       int
+	Participants int
+}
+
+type ActivityBest struct {
+	Activity string
+	BestRank int // 0 when never above the threshold
+	BestKC   int
+}
+
+// GetMemberStats puts together a member's record from the competition history
+// and the overall ranking.
+func GetMemberStats(guildID, discordId string) (MemberStats, error) {
+	stats := MemberStats{DiscordId: discordId}
+
+	participants, err := GetParticipantsInOrder(guildID)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	rank := 0
+	previousPoints := -1
+	for i, participant := range participants {
+		if participant.Points != previousPoints {
+			rank = i + 1
+		}
+		previousPoints = participant.Points
+
+		if participant.DiscordId == discordId {
+			stats.Points = participant.Points
+			if participant.Points > 0 {
+				stats.OverallRank = rank
+			}
+			break
+		}
+	}
+
+	history, err := GetCompetitionHistory(guildID)
+	if err != nil {
+		return stats, fmt.Errorf("failed to get competition history: %w", err)
+	}
+
+	best := map[string]ActivityBest{}
+	accountKC := map[string]int{}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		result := history[i]
+		for _, standing := range result.Standings {
+			if standing.DiscordId != discordId {
+				continue
+			}
+
+			stats.Events = append(stats.Events, EventPlacement{
+				Activity:     result.Activity,
+				EndedAt:      result.EndedAt,
+				Rank:         standing.Rank,
+				TotalKC:      standing.TotalKC,
+				Points:       standing.Points,
+				Participants: len(result.Standings),
+			})
+
+			if standing.Rank == 1 {
+				stats.Wins++
+			}
+			if standing.Rank >= 1 && standing.Rank <= 3 {
+				stats.Podiums++
+			}
+
+			activityBest := best[result.Activity]
+			activityBest.Activity = result.Activity
+			if standing.Rank > 0 && (activityBest.BestRank == 0 || standing.Rank < activityBest.BestRank) {
+				activityBest.BestRank = standing.Rank
+			}
+			activityBest.BestKC = max(activityBest.BestKC, standing.TotalKC)
+			best[result.Activity] = activityBest
+
+			for _, account := range standing.Accounts {
+				accountKC[account.Name] += account.KC
+			}
+		}
+	}
+
+	for _, activityBest := range best {
+		stats.BestPerActivity = append(stats.BestPerActivity, activityBest)
+	}
+	sort.Slice(stats.BestPerActivity, func(i, j int) bool {
+		return stats.BestPerActivity[i].Activity < stats.BestPerActivity[j].Activity
+	})
+
+	for name, kc := range accountKC {
+		stats.Accounts = append(stats.Accounts, AccountKC{AccountName: name, TotalKC: kc})
+	}
+	sort.Slice(stats.Accounts, func(i, j int) bool {
+		return stats.Accounts[i].TotalKC > stats.Accounts[j].TotalKC
+	})
+
+	return stats, nil
+}
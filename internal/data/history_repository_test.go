@@ -0,0 +1,88 @@
+package data
+
+import "testing"
+
+func TestPointsForRank(t *testing.T) {
+	want := []int{12, 9, 7, 5, 4, 3, 3, 2, 2, 2, 1, 1}
+	for i, points := range want {
+		if got := PointsForRank(i + 1); got != points {
+			t.Errorf("PointsForRank(%d) = %d, want %d", i+1, got, points)
+		}
+	}
+}
+
+func TestCompetitionResultTies(t *testing.T) {
+	useTestAssets(t)
+
+	const guildID = "guild"
+	saveCompetitionData(guildID, Competition{CurrentBoss: "Zulrah"})
+
+	participants := map[string]Participant{}
+	for discordId, kc := range map[string]int{"1": 50, "2": 40, "3": 40, "4": 30, "5": 20} {
+		participants[discordId] = Participant{
+			DiscordId: discordId,
+			LinkedOSRSAccounts: map[string]OSRSAccount{
+				"account" + discordId: {
+					Name: "Account" + discordId,
+					Activities: map[string]OSRSActivity{
+						"Zulrah": {Name: "Zulrah", StartAmount: 0, CurrentAmount: kc},
+					},
+				},
+			},
+		}
+	}
+	if err := saveParticipantsData(guildID, participants); err != nil {
+		t.Fatal(err)
+	}
+
+	competition, err := getCompetitionData(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := competitionResult(guildID, *competition)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Equal KC shares a rank and the next rank is skipped, 20 KC is below
+	// Zulrah's threshold of 25
+	want := map[string][2]int{
+		"1": {1, 12},
+		"2": {2, 9},
+		"3": {2, 9},
+		"4": {4, 5},
+		"5": {0, 0},
+	}
+	for _, standing := range result.Standings {
+		if got := [2]int{standing.Rank, standing.Points}; got != want[standing.DiscordId] {
+			t.Errorf("participant %s got rank and points %v, want %v", standing.DiscordId, got, want[standing.DiscordId])
+		}
+	}
+
+	if err := calculatePointsForParticipants(guildID); err != nil {
+		t.Fatal(err)
+	}
+	ranked, err := GetParticipantsInOrder(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ranking := exportRanking(ranked)
+	wantRanking := []ExportRanking{
+		{DiscordId: "1", Rank: 1, Points: 12},
+		{Rank: 2, Points: 9},
+		{Rank: 2, Points: 9},
+		{DiscordId: "4", Rank: 4, Points: 5},
+	}
+	if len(ranking) != len(wantRanking) {
+		t.Fatalf("ranking = %+v, want %d entries", ranking, len(wantRanking))
+	}
+	for i, entry := range ranking {
+		// The order within a tie isn't defined
+		if wantRanking[i].DiscordId != "" && entry.DiscordId != wantRanking[i].DiscordId {
+			t.Errorf("ranking[%d] is %s, want %s", i, entry.DiscordId, wantRanking[i].DiscordId)
+		}
+		if entry.Rank != wantRanking[i].Rank || entry.Points != wantRanking[i].Points {
+			t.Errorf("ranking[%d] = %+v, want rank %d with %d points", i, entry, wantRanking[i].Rank, wantRanking[i].Points)
+		}
+	}
+}
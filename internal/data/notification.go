@@ -0,0 +1,64 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// NotificationSettings are the notifications a member opted in to.
+type NotificationSettings struct {
+	// Overtake is sent when someone passes the member on the leaderboard.
+	Overtake bool `json:"overtake,omitempty"`
+	// Threshold is sent when the member reaches the activity threshold.
+	Threshold bool `json:"threshold,omitempty"`
+	// Milestone is sent when the member reaches a round KC number.
+	Milestone bool `json:"milestone,omitempty"`
+	// InChannel pings the member in the announcement channel instead of sending a DM.
+	InChannel bool `json:"inChannel,omitempty"`
+}
+
+// Any reports whether the member gets any notifications at all.
+func (n NotificationSettings) Any() bool {
+	return n.Overtake || n.Threshold || n.Milestone
+}
+
+const notificationsFilePath = "%s_notifications.json"
+
+// getNotificationSettings returns the settings of every member that changed
+// them, by Discord ID.
+func getNotificationSettings(guildID string) (map[string]NotificationSettings, error) {
+	data, err := os.ReadFile(assetPath(notificationsFilePath, guildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]NotificationSettings{}, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	settings := map[string]NotificationSettings{}
+	if len(data) == 0 {
+		return settings, nil
+	}
+
+	err = json.Unmarshal(data, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return settings, nil
+}
+
+func saveNotificationSettings(guildID string, settings map[string]NotificationSettings) error {
+	data, err := json.MarshalIndent(settings, "", "  ") // Pretty-print
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification settings: %w", err)
+	}
+
+	err = os.WriteFile(assetPath(notificationsFilePath, guildID), data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package data
+
+import (
+	"fmt"
+	"misclicked-events/internal/constants"
+	"sort"
+)
+
+// kcMilestones are the round KC numbers worth a notification, after the last
+// one every thousand KC is.
+var kcMilestones = []int{10, 25, 50, 100, 250, 500, 750, 1000}
+
+// NotificationKind is what happened to a member between two updates.
+type NotificationKind int
+
+const (
+	// NotifyOvertaken means OtherId passed the member.
+	NotifyOvertaken NotificationKind = iota
+	// NotifyThreshold means the member reached the activity threshold.
+	NotifyThreshold
+	// NotifyMilestone means the member reached Milestone KC.
+	NotifyMilestone
+)
+
+// StandingsNotification is something to tell a member about their standing.
+type StandingsNotification struct {
+	Kind      NotificationKind
+	DiscordId string
+	// OtherId is the participant that overtook the member.
+	OtherId   string
+	KC        int
+	OtherKC   int
+	Milestone int
+	// InChannel is set when the member wants to be pinged instead of DMed.
+	InChannel bool
+}
+
+// GetNotificationSettingsFor returns the notifications the member opted in
+// to, none by default.
+func GetNotificationSettingsFor(guildID, discordId string) (NotificationSettings, error) {
+	settings, err := getNotificationSettings(guildID)
+	if err != nil {
+		return NotificationSettings{}, err
+	}
+	return settings[discordId], nil
+}
+
+// UpdateNotificationSettings saves the notifications the member opted in to.
+func UpdateNotificationSettings(guildID, discordId string, memberSettings NotificationSettings) error {
+	settings, err := getNotificationSettings(guildID)
+	if err != nil {
+		return err
+	}
+
+	if memberSettings == (NotificationSettings{}) {
+		delete(settings, discordId)
+	} else {
+		settings[discordId] = memberSettings
+	}
+
+	err = saveNotificationSettings(guildID, settings)
+	if err != nil {
+		return fmt.Errorf("failed to save notification settings: %w", err)
+	}
+
+	return nil
+}
+
+// StandingsNotifications compares the latest two snapshots of the running
+// event and returns what the members that opted in should hear about,
+// grouped by member. It's meant to be called right after
+// RecordStandingsSnapshot.
+func StandingsNotifications(guildID string) ([]StandingsNotification, error) {
+	settings, err := getNotificationSettings(guildID)
+	if err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	snapshots, err := GetStandingsSnapshots(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := GetCurrentBoss(guildID)
+	if len(snapshots) < 2 || snapshots[0].Activity != activity {
+		return nil, nil
+	}
+
+	previous := snapshots[len(snapshots)-2]
+	latest := snapshots[len(snapshots)-1]
+	threshold := constants.Activities[activity].Threshold
+
+	// Participants that joined since the previous update had no KC then
+	previousKC := func(discordId string) int {
+		entry, _ := previous.Entry(discordId)
+		return entry.TotalKC
+	}
+
+	var notifications []StandingsNotification
+	for _, member := range latest.Participants {
+		memberSettings := settings[member.DiscordId]
+		if !memberSettings.Any() {
+			continue
+		}
+		before := previousKC(member.DiscordId)
+
+		if memberSettings.Overtake {
+			for _, other := range latest.Participants {
+				if other.DiscordId == member.DiscordId {
+					continue
+				}
+				if previousKC(other.DiscordId) < before && other.TotalKC > member.TotalKC {
+					notifications = append(notifications, StandingsNotification{
+						Kind:      NotifyOvertaken,
+						DiscordId: member.DiscordId,
+						OtherId:   other.DiscordId,
+						KC:        member.TotalKC,
+						OtherKC:   other.TotalKC,
+					})
+				}
+			}
+		}
+
+		if memberSettings.Threshold && before < threshold && member.TotalKC >= threshold {
+			notifications = append(notifications, StandingsNotification{
+				Kind:      NotifyThreshold,
+				DiscordId: member.DiscordId,
+				KC:        member.TotalKC,
+			})
+		}
+
+		if milestone := crossedMilestone(before, member.TotalKC); memberSettings.Milestone && milestone > 0 {
+			notifications = append(notifications, StandingsNotification{
+				Kind:      NotifyMilestone,
+				DiscordId: member.DiscordId,
+				KC:        member.TotalKC,
+				Milestone: milestone,
+			})
+		}
+	}
+
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].DiscordId < notifications[j].DiscordId
+	})
+
+	for i := range notifications {
+		notifications[i].InChannel = settings[notifications[i].DiscordId].InChannel
+	}
+
+	return notifications, nil
+}
+
+// crossedMilestone returns the highest milestone in (before, after], or 0
+// when there is none.
+func crossedMilestone(before, after int) int {
+	highest := 0
+	for _, milestone := range kcMilestones {
+		if before < milestone && milestone <= after {
+			highest = milestone
+		}
+	}
+
+	last := kcMilestones[len(kcMilestones)-1]
+	if after > last {
+		if thousands := after / 1000 * 1000; thousands > before && thousands > last {
+			highest = thousands
+		}
+	}
+
+	return highest
+}
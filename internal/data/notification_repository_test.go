@@ -0,0 +1,29 @@
+package data
+
+import "testing"
+
+func TestCrossedMilestone(t *testing.T) {
+	tests := []struct {
+		before, after int
+		want          int
+	}{
+		{0, 9, 0},
+		{9, 10, 10},
+		{10, 24, 0},
+		{10, 25, 25},
+		{0, 60, 50},
+		{99, 101, 100},
+		{999, 1000, 1000},
+		{1000, 1999, 0},
+		{1999, 2000, 2000},
+		{1500, 4200, 4000},
+		{900, 2100, 2000},
+		{50, 50, 0},
+	}
+
+	for _, tt := range tests {
+		if got := crossedMilestone(tt.before, tt.after); got != tt.want {
+			t.Errorf("crossedMilestone(%d, %d) = %d, want %d", tt.before, tt.after, got, tt.want)
+		}
+	}
+}
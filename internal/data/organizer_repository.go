@@ -0,0 +1,65 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	ErrOrganizerRoleExists   = errors.New("organizer role already exists")
+	ErrOrganizerRoleNotFound = errors.New("organizer role not found")
+)
+
+// AddOrganizerRole lets members with the role run the events.
+func AddOrganizerRole(guildID, roleID string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	if slices.Contains(config.OrganizerRoleIDs, roleID) {
+		return ErrOrganizerRoleExists
+	}
+
+	config.OrganizerRoleIDs = append(config.OrganizerRoleIDs, roleID)
+	return SaveBotConfig(guildID, *config)
+}
+
+// RemoveOrganizerRole stops members with the role from running the events.
+func RemoveOrganizerRole(guildID, roleID string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	index := slices.Index(config.OrganizerRoleIDs, roleID)
+	if index < 0 {
+		return ErrOrganizerRoleNotFound
+	}
+
+	config.OrganizerRoleIDs = slices.Delete(config.OrganizerRoleIDs, index, index+1)
+	return SaveBotConfig(guildID, *config)
+}
+
+// GetOrganizerRoles returns the roles that run the guild's events.
+func GetOrganizerRoles(guildID string) []string {
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return nil
+	}
+	return config.OrganizerRoleIDs
+}
+
+// IsOrganizer reports whether any of the member's roles is an organizer role.
+func IsOrganizer(guildID string, memberRoles []string) bool {
+	return slices.ContainsFunc(GetOrganizerRoles(guildID), func(roleID string) bool {
+		return slices.Contains(memberRoles, roleID)
+	})
+}
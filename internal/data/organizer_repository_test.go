@@ -0,0 +1,32 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentOrganizerRolesAreAllKept(t *testing.T) {
+	useTestAssets(t)
+
+	const guildID = "guild"
+	if err := SaveBotConfig(guildID, BotConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for n := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := AddOrganizerRole(guildID, fmt.Sprint(n)); err != nil {
+				t.Errorf("AddOrganizerRole(%d): %v", n, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if roles := GetOrganizerRoles(guildID); len(roles) != 20 {
+		t.Errorf("kept %d organizer roles, want 20", len(roles))
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"maps"
 	"os"
+	"path/filepath"
 	"slices"
+	"time"
+
+	"misclicked-events/internal/utils"
 
 	"golang.org/x/text/cases"
 )
@@ -17,24 +21,45 @@ type participantDto struct {
 }
 
 type oSRSAccountDto struct {
-	Name       string        `json:"name"`
-	Activities []activityDto `json:"activities"`
+	Name          string        `json:"name"`
+	Activities    []activityDto `json:"activities"`
+	FailedUpdates int           `json:"failedUpdates,omitempty"`
+	Stale         bool          `json:"stale,omitempty"`
+	Unverified    bool          `json:"unverified,omitempty"`
+	// Challenge is the pending ownership challenge of an unverified account.
+	Challenge *VerificationChallenge `json:"challenge,omitempty"`
 }
 
 type activityDto struct {
-	Name          string `json:"name"`
-	StartAmount   int    `json:"startAmount"`
-	CurrentAmount int    `json:"currentAmount"`
+	Name          string    `json:"name"`
+	StartAmount   int       `json:"startAmount"`
+	CurrentAmount int       `json:"currentAmount"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	ChangedAt     time.Time `json:"changedAt"`
 }
 
 const (
 	ErrParticipantNotFound = "participant not found"
-	filePath               = "./assets/%s_participants.json"
+	filePath               = "%s_participants.json"
 )
 
+// assetsDir holds the data files of every guild.
+var assetsDir = "./assets"
+
+// assetPath returns the path of a guild's data file, name is formatted with
+// the guild ID.
+func assetPath(name, guildID string) string {
+	return filepath.Join(assetsDir, fmt.Sprintf(name, guildID))
+}
+
+// guildLocks serializes everything that reads, changes and saves the data of
+// a guild. Without it the last save wins and drops whatever was saved in the
+// meantime. Exported functions take the lock, so they must not call each other.
+var guildLocks utils.KeyedMutex
+
 func getParticipants(guildID string) (map[string]Participant, error) {
 	// Open the file for reading
-	file, err := os.Open(fmt.Sprintf(filePath, guildID))
+	file, err := os.Open(assetPath(filePath, guildID))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
@@ -79,7 +104,7 @@ func saveParticipantsData(guildID string, participants map[string]Participant) e
 	}
 
 	// Create or overwrite the file
-	file, err := os.Create(fmt.Sprintf(filePath, guildID))
+	file, err := os.Create(assetPath(filePath, guildID))
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
@@ -113,8 +138,12 @@ func mapParticipantsToDto(participants map[string]Participant) []participantDto
 			}
 
 			linkedAccountsDto = append(linkedAccountsDto, oSRSAccountDto{
-				Name:       a.Name,
-				Activities: activitiesDto,
+				Name:          a.Name,
+				Activities:    activitiesDto,
+				FailedUpdates: a.FailedUpdates,
+				Stale:         a.Stale,
+				Unverified:    a.Unverified,
+				Challenge:     a.Challenge,
 			})
 		}
 
@@ -139,8 +168,12 @@ func mapParticipantsDto(participantsDto []participantDto) map[string]Participant
 			}
 
 			accounts[cases.Fold().String(a.Name)] = OSRSAccount{
-				Name:       a.Name,
-				Activities: activities,
+				Name:          a.Name,
+				Activities:    activities,
+				FailedUpdates: a.FailedUpdates,
+				Stale:         a.Stale,
+				Unverified:    a.Unverified,
+				Challenge:     a.Challenge,
 			}
 		}
 
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"misclicked-events/internal/constants"
@@ -8,10 +9,21 @@ import (
 	"misclicked-events/internal/utils"
 	"slices"
 	"sort"
+	"strings"
+	"time"
 
 	"golang.org/x/text/cases"
 )
 
+// Errors members can run into, commands translate them.
+var (
+	ErrUnknownAccount        = errors.New("could not find an OSRS account with this username")
+	ErrAccountAlreadyTracked = errors.New("account is already being tracked")
+	ErrNoTrackedAccounts     = errors.New("no accounts are being tracked for this member")
+	ErrAccountNotFound       = errors.New("no account found by this name")
+	ErrSameAccountOwner      = errors.New("the account already belongs to this member")
+)
+
 type Participant struct {
 	DiscordId          string
 	Points             int
@@ -23,11 +35,20 @@ func (p Participant) TotalKCForActivity(activityName string) (int, []AccountKC)
 	var accountBreakdown []AccountKC
 
 	for _, account := range p.LinkedOSRSAccounts {
+		if account.Unverified {
+			continue
+		}
 		accountKC := account.KCForActivity(activityName)
-		if accountKC > 0 { // Include accounts contributing more than 0 KC
+		activity, participating := account.Activities[activityName]
+		// Include accounts contributing more than 0 KC, and stale ones so
+		// their owner sees why they don't
+		if accountKC > 0 || (account.Stale && participating) {
 			accountBreakdown = append(accountBreakdown, AccountKC{
 				AccountName: account.Name,
 				TotalKC:     accountKC,
+				StartKC:     activity.StartAmount,
+				CurrentKC:   activity.CurrentAmount,
+				Stale:       account.Stale,
 			})
 			totalKC += accountKC
 		}
@@ -41,9 +62,32 @@ func (p Participant) TotalKCForActivity(activityName string) (int, []AccountKC)
 	return totalKC, accountBreakdown
 }
 
+// HasActivity reports whether any of the participant's verified accounts
+// takes part in the activity.
+func (p Participant) HasActivity(activityName string) bool {
+	for _, account := range p.LinkedOSRSAccounts {
+		if account.Unverified {
+			continue
+		}
+		if _, exists := account.Activities[activityName]; exists {
+			return true
+		}
+	}
+	return false
+}
+
 type OSRSAccount struct {
 	Name       string
 	Activities map[string]OSRSActivity
+	// FailedUpdates counts consecutive updates where the hiscores didn't know the account.
+	FailedUpdates int
+	// Stale is set once FailedUpdates reaches StaleAfterFailedUpdates, the
+	// account keeps its last known KC until it shows up on the hiscores again.
+	Stale bool
+	// Unverified accounts were tracked while the guild requires verification
+	// and haven't passed a challenge yet, their KC doesn't count.
+	Unverified bool
+	Challenge  *VerificationChallenge
 }
 
 func (acc OSRSAccount) KCForActivity(activityName string) int {
@@ -58,10 +102,17 @@ type OSRSActivity struct {
 	Name          string
 	StartAmount   int
 	CurrentAmount int
+	// UpdatedAt is when CurrentAmount was last read from the hiscores.
+	UpdatedAt time.Time
+	// ChangedAt is when CurrentAmount last changed. The hiscores only update
+	// when a player logs out, so a session shows up as a single change.
+	ChangedAt time.Time
 }
 
+// KC is what the account gained during the event. A rollback or a rename can
+// take the hiscores below where the event started, that counts as nothing.
 func (a OSRSActivity) KC() int {
-	return a.CurrentAmount - a.StartAmount
+	return max(0, a.CurrentAmount-a.StartAmount)
 }
 
 type ParticipantKC struct {
@@ -73,9 +124,46 @@ type ParticipantKC struct {
 type AccountKC struct {
 	AccountName string
 	TotalKC     int
+	// StartKC and CurrentKC are what the hiscores said at the start of the
+	// event and at the last update.
+	StartKC   int
+	CurrentKC int
+	Stale     bool
+}
+
+// StaleAfterFailedUpdates is the number of consecutive updates an account can
+// be missing from the hiscores before it is marked stale.
+const StaleAfterFailedUpdates = 3
+
+// KCUpdateReport describes what happened during UpdateAccountsKC that
+// someone may need to act on.
+type KCUpdateReport struct {
+	// NewlyStale holds the accounts that were marked stale during this update.
+	NewlyStale []StaleAccount
+	// NewReviews holds the KC changes that were held back for an admin to review.
+	NewReviews []KCReview
+	// MissingBossNames holds the catalog boss names the hiscores didn't list.
+	MissingBossNames []string
+	// Validated counts the hiscore responses that were checked against the
+	// catalog, without any MissingBossNames says nothing when it's 0.
+	Validated int
+	// Blocked is set when MissingBossNames affects the current activity, in
+	// which case no KC was updated.
+	Blocked bool
+	// Unavailable is set when the hiscores went down during the update, the
+	// accounts that weren't updated yet keep their last known KC.
+	Unavailable bool
+}
+
+type StaleAccount struct {
+	DiscordId   string
+	AccountName string
 }
 
 func TrackAccount(guildID, username, discordId string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
 	// Retrieve participants for the guild
 	participants, err := getParticipants(guildID)
 	if err != nil {
@@ -88,6 +176,12 @@ func TrackAccount(guildID, username, discordId string) error {
 		participants = make(map[string]Participant)
 	}
 
+	// An account only counts for one member, disputes go through a claim
+	err = checkAccountOwner(participants, username, discordId)
+	if err != nil {
+		return err
+	}
+
 	// Get the current competition boss (if any)
 	currentBoss := GetCurrentBoss(guildID)
 
@@ -97,7 +191,7 @@ func TrackAccount(guildID, username, discordId string) error {
 	if !exists {
 		// Validate the username
 		if !service.CheckIfPlayerExists(username) {
-			err := fmt.Errorf("could not find an OSRS account with the username: %s", username)
+			err := fmt.Errorf("%w: %s", ErrUnknownAccount, username)
 			utils.LogError("Invalid username", err)
 			return err
 		}
@@ -113,7 +207,7 @@ func TrackAccount(guildID, username, discordId string) error {
 	} else {
 		// Validate the username
 		if !service.CheckIfPlayerExists(username) {
-			err := fmt.Errorf("could not find an OSRS account with the username: %s", username)
+			err := fmt.Errorf("%w: %s", ErrUnknownAccount, username)
 			utils.LogError("Invalid username", err)
 			return err
 		}
@@ -128,6 +222,15 @@ func TrackAccount(guildID, username, discordId string) error {
 		participants[discordId] = participant
 	}
 
+	// New accounts only count once their owner proves it's theirs
+	if IsVerificationRequired(guildID) {
+		account := participants[discordId].LinkedOSRSAccounts[cases.Fold().String(username)]
+		account.Unverified = true
+		saveAccount(participants, discordId, account)
+	} else {
+		releaseUnverifiedCopies(participants, username, discordId)
+	}
+
 	// Save the updated participants map
 	err = saveParticipantsData(guildID, participants)
 	if err != nil {
@@ -138,19 +241,37 @@ func TrackAccount(guildID, username, discordId string) error {
 	return nil
 }
 
-func UpdateAccountsKC(guildID string) error {
+// UpdateAccountsKC fetches the KC of every tracked account for the running event.
+func UpdateAccountsKC(guildID string) (KCUpdateReport, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	return updateAccountsKC(guildID)
+}
+
+func updateAccountsKC(guildID string) (KCUpdateReport, error) {
+	var report KCUpdateReport
+
 	// Fetch all participants
 	participants, err := getParticipants(guildID)
 	if err != nil {
-		return fmt.Errorf("failed to fetch participants: %w", err)
+		return report, fmt.Errorf("failed to fetch participants: %w", err)
 	}
 
 	// Get the currently ongoing boss
 	currentBoss := GetCurrentBoss(guildID)
 	if currentBoss == "" {
-		return fmt.Errorf("no ongoing boss competition")
+		return report, fmt.Errorf("no ongoing boss competition")
 	}
 
+	reviews, err := getKCReviews(guildID)
+	if err != nil {
+		return report, fmt.Errorf("failed to fetch KC reviews: %w", err)
+	}
+	canReview := canReviewKC(guildID)
+
+	var unavailableErr error
+
 	// Iterate through each participant
 	for discordId, participant := range participants {
 		updated := false
@@ -158,26 +279,83 @@ func UpdateAccountsKC(guildID string) error {
 		// Iterate through each linked OSRS account
 		for username, account := range participant.LinkedOSRSAccounts {
 			// Fetch the current KC for the account and boss
-			kc, err := fetchKc(username, currentBoss)
+			kc, missing, err := fetchValidatedKc(username, currentBoss)
+			if err == nil {
+				report.Validated++
+			}
+			if err == nil && len(missing) > 0 {
+				for _, name := range missing {
+					if !slices.Contains(report.MissingBossNames, name) {
+						report.MissingBossNames = append(report.MissingBossNames, name)
+					}
+				}
+
+				// The KC would silently read 0, keep the last known KC instead
+				if blocked := missingForActivity(currentBoss, missing); len(blocked) > 0 {
+					report.Blocked = true
+					continue
+				}
+			}
+			if errors.Is(err, service.ErrHiscoresUnavailable) {
+				// No point in asking for the rest, the others keep their KC
+				unavailableErr = err
+				break
+			}
 			if err != nil {
 				fmt.Printf("Error fetching KC for account %s: %v\n", username, err)
+
+				// Only a missing player counts towards going stale, anything
+				// else is a problem on the hiscores' side.
+				if errors.Is(err, service.ErrPlayerNotFound) {
+					account.FailedUpdates++
+					if !account.Stale && account.FailedUpdates >= StaleAfterFailedUpdates {
+						account.Stale = true
+						report.NewlyStale = append(report.NewlyStale, StaleAccount{
+							DiscordId:   discordId,
+							AccountName: account.Name,
+						})
+					}
+					participant.LinkedOSRSAccounts[username] = account
+					updated = true
+				}
 				continue
 			}
 
+			account.FailedUpdates = 0
+			account.Stale = false
+
 			// Update the activity if it exists
 			if account.Activities == nil {
 				account.Activities = make(map[string]OSRSActivity)
 			}
 
 			if activity, exists := account.Activities[currentBoss]; exists {
-				activity.CurrentAmount = kc
-				account.Activities[currentBoss] = activity
+				if hasPendingReview(reviews, discordId, username, currentBoss) {
+					// Leave the KC alone until an admin looked at the earlier change
+				} else if canReview && isImplausibleChange(activity, kc, currentBoss) {
+					review, err := newKCReview(discordId, username, account.Name, activity, kc)
+					if err != nil {
+						fmt.Printf("Error creating KC review for account %s: %v\n", username, err)
+					} else {
+						reviews = append(reviews, review)
+						report.NewReviews = append(report.NewReviews, review)
+					}
+				} else {
+					if kc != activity.CurrentAmount {
+						activity.ChangedAt = time.Now()
+					}
+					activity.CurrentAmount = kc
+					activity.UpdatedAt = time.Now()
+					account.Activities[currentBoss] = activity
+				}
 			} else {
 				// Add a new activity if not already tracked
 				account.Activities[currentBoss] = OSRSActivity{
 					Name:          currentBoss,
 					StartAmount:   kc,
 					CurrentAmount: kc,
+					UpdatedAt:     time.Now(),
+					ChangedAt:     time.Now(),
 				}
 			}
 
@@ -189,18 +367,62 @@ func UpdateAccountsKC(guildID string) error {
 		if updated {
 			participants[discordId] = participant
 		}
+
+		if unavailableErr != nil {
+			break
+		}
 	}
 
 	// Save updated participants
 	err = saveParticipantsData(guildID, participants)
 	if err != nil {
-		return fmt.Errorf("failed to save updated participants: %w", err)
+		return report, fmt.Errorf("failed to save updated participants: %w", err)
 	}
 
-	return nil
+	if len(report.MissingBossNames) > 0 {
+		utils.LogError(fmt.Sprintf("Hiscores are missing boss names: %s", strings.Join(report.MissingBossNames, ", ")), nil)
+	}
+
+	if len(report.NewReviews) > 0 {
+		err = saveKCReviews(guildID, reviews)
+		if err != nil {
+			return report, fmt.Errorf("failed to save KC reviews: %w", err)
+		}
+	}
+
+	// What was fetched before the hiscores went down is saved, the caller
+	// still needs to know the update didn't finish
+	if unavailableErr != nil {
+		report.Unavailable = true
+		return report, unavailableErr
+	}
+
+	return report, nil
 }
 
 func GetParticipantsByActivityKCThreshold(guildID string) ([]ParticipantKC, error) {
+	participantKC, err := GetParticipantsByActivityKC(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	activityName := GetCurrentBoss(guildID)
+
+	var result []ParticipantKC
+
+	// Only keep participants whose total KC reaches the threshold
+	for _, participant := range participantKC {
+		if participant.TotalKC >= constants.Activities[activityName].Threshold {
+			result = append(result, participant)
+		}
+	}
+
+	return result, nil
+}
+
+// GetParticipantsByActivityKC returns everyone taking part in the current
+// event, including those below the threshold, sorted by TotalKC.
+func GetParticipantsByActivityKC(guildID string) ([]ParticipantKC, error) {
 	// Fetch participants for the given guild
 	participants, err := getParticipants(guildID)
 	if err != nil {
@@ -216,17 +438,18 @@ func GetParticipantsByActivityKCThreshold(guildID string) ([]ParticipantKC, erro
 
 	// Iterate through participants
 	for _, participant := range participants {
+		if !participant.HasActivity(activityName) {
+			continue
+		}
+
 		// Use the Participant method to calculate total KC and breakdown
 		totalKC, accountBreakdown := participant.TotalKCForActivity(activityName)
 
-		// Add participant to the result if their total KC exceeds the threshold
-		if totalKC >= constants.Activities[activityName].Threshold {
-			result = append(result, ParticipantKC{
-				DiscordId:  participant.DiscordId,
-				TotalKC:    totalKC,
-				AccountKCs: accountBreakdown,
-			})
-		}
+		result = append(result, ParticipantKC{
+			DiscordId:  participant.DiscordId,
+			TotalKC:    totalKC,
+			AccountKCs: accountBreakdown,
+		})
 	}
 
 	// Sort the result by TotalKC in descending order
@@ -252,6 +475,8 @@ func createNewParticipant(discordId, username, currentBoss string) (Participant,
 			Name:          currentBoss,
 			StartAmount:   kc,
 			CurrentAmount: kc,
+			UpdatedAt:     time.Now(),
+			ChangedAt:     time.Now(),
 		}
 	}
 
@@ -259,7 +484,7 @@ func createNewParticipant(discordId, username, currentBoss string) (Participant,
 		DiscordId: discordId,
 		Points:    0,
 		LinkedOSRSAccounts: map[string]OSRSAccount{
-			username: {
+			cases.Fold().String(username): {
 				Name:       username,
 				Activities: activities,
 			},
@@ -272,7 +497,7 @@ func createNewParticipant(discordId, username, currentBoss string) (Participant,
 func addAccountToParticipant(participant *Participant, username, currentBoss string) error {
 	usernameKey := cases.Fold().String(username)
 	if _, exists := participant.LinkedOSRSAccounts[usernameKey]; exists {
-		return fmt.Errorf("account is already being tracked")
+		return ErrAccountAlreadyTracked
 	}
 
 	activities := map[string]OSRSActivity{}
@@ -287,6 +512,8 @@ func addAccountToParticipant(participant *Participant, username, currentBoss str
 			Name:          currentBoss,
 			StartAmount:   kc,
 			CurrentAmount: kc,
+			UpdatedAt:     time.Now(),
+			ChangedAt:     time.Now(),
 		}
 	}
 
@@ -298,13 +525,41 @@ func addAccountToParticipant(participant *Participant, username, currentBoss str
 	return nil
 }
 
+// SchemaError is returned when the hiscores no longer list boss names the
+// activity catalog relies on, so the KC can't be trusted.
+type SchemaError struct {
+	Activity string
+	Missing  []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("hiscores are missing %s for %s", strings.Join(e.Missing, ", "), e.Activity)
+}
+
 // fetchKc calculates the total KC for the given username and boss.
 func fetchKc(username, bossId string) (int, error) {
+	kc, missing, err := fetchValidatedKc(username, bossId)
+	if err != nil {
+		return 0, err
+	}
+
+	if blocked := missingForActivity(bossId, missing); len(blocked) > 0 {
+		return 0, &SchemaError{Activity: bossId, Missing: blocked}
+	}
+
+	return kc, nil
+}
+
+// fetchValidatedKc calculates the total KC for the given username and boss, and
+// checks the response against every boss name in the activity catalog.
+func fetchValidatedKc(username, bossId string) (int, []string, error) {
 	_, activities, err := service.FetchHiscore(username)
 	if err != nil {
-		return 0, fmt.Errorf("failed to fetch hiscores for %s: %w", username, err)
+		return 0, nil, fmt.Errorf("failed to fetch hiscores for %s: %w", username, err)
 	}
 
+	missing := service.MissingActivities(activities, constants.BossNames())
+
 	kc := 0
 	for _, activityName := range constants.Activities[bossId].BossNames {
 		if activity, exists := service.FindActivity(activities, activityName); exists {
@@ -312,7 +567,18 @@ func fetchKc(username, bossId string) (int, error) {
 		}
 	}
 
-	return max(0, kc), nil
+	return max(0, kc), missing, nil
+}
+
+// missingForActivity returns the missing names the given activity depends on.
+func missingForActivity(bossId string, missing []string) []string {
+	var blocked []string
+	for _, name := range missing {
+		if slices.Contains(constants.Activities[bossId].BossNames, name) {
+			blocked = append(blocked, name)
+		}
+	}
+	return blocked
 }
 
 func GetParticipantsInOrder(guildID string) ([]Participant, error) {
@@ -331,6 +597,9 @@ func GetParticipantsInOrder(guildID string) ([]Participant, error) {
 }
 
 func UntrackAccount(guildID, username, discordId string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
 	participants, err := getParticipants(guildID)
 	if err != nil {
 		return err
@@ -339,14 +608,14 @@ func UntrackAccount(guildID, username, discordId string) error {
 	participant, ok := participants[discordId]
 
 	if !ok {
-		return fmt.Errorf("we are currently not tracking any accounts for you")
+		return ErrNoTrackedAccounts
 	}
 
 	usernameKey := cases.Fold().String(username)
 	_, ok = participant.LinkedOSRSAccounts[usernameKey]
 
 	if !ok {
-		return fmt.Errorf("no account found by this name")
+		return ErrAccountNotFound
 	}
 
 	delete(participant.LinkedOSRSAccounts, usernameKey)
@@ -368,7 +637,7 @@ func TrackedAccounts(guildId, discordId string) ([]OSRSAccount, error) {
 
 	participant, exists := participants[discordId]
 	if !exists {
-		return nil, fmt.Errorf("we are currently not tracking any accounts for you")
+		return nil, ErrNoTrackedAccounts
 	}
 
 	accounts := make([]OSRSAccount, 0, len(participant.LinkedOSRSAccounts))
@@ -379,8 +648,19 @@ func TrackedAccounts(guildId, discordId string) ([]OSRSAccount, error) {
 	return accounts, nil
 }
 
-// CalculatePointsForParticipants calculates and assigns points to participants based on their TotalKC.
-func CalculatePointsForParticipants(guildID string) error {
+// pointSystem holds the points for ranks 1 through 10
+var pointSystem = []int{12, 9, 7, 5, 4, 3, 3, 2, 2, 2}
+
+// PointsForRank returns the points a participant above the threshold earns for their rank.
+func PointsForRank(rank int) int {
+	if rank <= len(pointSystem) {
+		return pointSystem[rank-1]
+	}
+	return 1 // Default point for ranks beyond the defined system
+}
+
+// calculatePointsForParticipants calculates and assigns points to participants based on their TotalKC.
+func calculatePointsForParticipants(guildID string) error {
 	// Get participants above the threshold, sorted by TotalKC (descending)
 	participantsAboveThreshold, err := GetParticipantsByActivityKCThreshold(guildID)
 	if err != nil {
@@ -393,9 +673,6 @@ func CalculatePointsForParticipants(guildID string) error {
 		return fmt.Errorf("failed to retrieve participants: %w", err)
 	}
 
-	// Define the point system
-	pointSystem := []int{12, 9, 7, 5, 4, 3, 3, 2, 2, 2} // Points for ranks 1 through 10
-
 	// Track current rank and previous KC
 	currentRank := 1
 	previousKC := -1
@@ -408,11 +685,7 @@ func CalculatePointsForParticipants(guildID string) error {
 			currentRank = i + 1
 
 			// Determine points to award based on rank
-			if currentRank <= len(pointSystem) {
-				pointsToAward = pointSystem[currentRank-1]
-			} else {
-				pointsToAward = 1 // Default point for ranks beyond the defined system
-			}
+			pointsToAward = PointsForRank(currentRank)
 		}
 
 		// Update the points for the participant in the map
@@ -437,6 +710,9 @@ func CalculatePointsForParticipants(guildID string) error {
 }
 
 func RenameAccount(guildID, oldUsername, newUsername, discordId string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
 	participants, err := getParticipants(guildID)
 	if err != nil {
 		return err
@@ -444,28 +720,103 @@ func RenameAccount(guildID, oldUsername, newUsername, discordId string) error {
 
 	participant, ok := participants[discordId]
 	if !ok {
-		return fmt.Errorf("we are currently not tracking any accounts for you")
+		return ErrNoTrackedAccounts
 	}
 
 	oldUsernameKey := cases.Fold().String(oldUsername)
 	account, ok := participant.LinkedOSRSAccounts[oldUsernameKey]
 	if !ok {
-		return fmt.Errorf("no account found with username: %s", oldUsername)
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, oldUsername)
 	}
 
 	newUsernameKey := cases.Fold().String(newUsername)
 	if _, exists := participant.LinkedOSRSAccounts[newUsernameKey]; exists {
-		return fmt.Errorf("you are already tracking an account with username: %s", newUsername)
+		return fmt.Errorf("%w: %s", ErrAccountAlreadyTracked, newUsername)
+	}
+	err = checkAccountOwner(participants, newUsername, discordId)
+	if err != nil {
+		return err
 	}
 
-	// Update the account name
+	// Update the account name, the new name gets a fresh chance on the hiscores
 	account.Name = newUsername
+	account.FailedUpdates = 0
+	account.Stale = false
+
+	// The new name could be anyone's account
+	if IsVerificationRequired(guildID) {
+		account.Unverified = true
+		account.Challenge = nil
+	}
 	delete(participant.LinkedOSRSAccounts, oldUsernameKey)
 	participant.LinkedOSRSAccounts[newUsernameKey] = account
 
 	// Update the participant in the map
 	participants[discordId] = participant
+	if !account.Unverified {
+		releaseUnverifiedCopies(participants, newUsername, discordId)
+	}
 
 	// Save the updated data
 	return saveParticipantsData(guildID, participants)
 }
+
+// TransferAccount moves an account, with the KC it started each activity on,
+// from one member to another. Pending KC reviews of the account move along.
+func TransferAccount(guildID, username, fromDiscordId, toDiscordId string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	return transferAccount(guildID, username, fromDiscordId, toDiscordId)
+}
+
+func transferAccount(guildID, username, fromDiscordId, toDiscordId string) error {
+	if fromDiscordId == toDiscordId {
+		return ErrSameAccountOwner
+	}
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		return err
+	}
+
+	from, ok := participants[fromDiscordId]
+	if !ok {
+		return ErrNoTrackedAccounts
+	}
+
+	usernameKey := cases.Fold().String(username)
+	account, ok := from.LinkedOSRSAccounts[usernameKey]
+	if !ok {
+		return ErrAccountNotFound
+	}
+
+	to, ok := participants[toDiscordId]
+	if !ok {
+		to = Participant{
+			DiscordId:          toDiscordId,
+			LinkedOSRSAccounts: map[string]OSRSAccount{},
+		}
+	}
+	if _, exists := to.LinkedOSRSAccounts[usernameKey]; exists {
+		return fmt.Errorf("%w: %s", ErrAccountAlreadyTracked, username)
+	}
+
+	delete(from.LinkedOSRSAccounts, usernameKey)
+	to.LinkedOSRSAccounts[usernameKey] = account
+
+	// Points stay with the member that earned them
+	if len(from.LinkedOSRSAccounts) == 0 && from.Points == 0 {
+		delete(participants, fromDiscordId)
+	} else {
+		participants[fromDiscordId] = from
+	}
+	participants[toDiscordId] = to
+
+	err = saveParticipantsData(guildID, participants)
+	if err != nil {
+		return fmt.Errorf("failed to save participants: %w", err)
+	}
+
+	return transferKCReviews(guildID, usernameKey, fromDiscordId, toDiscordId)
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"misclicked-events/internal/fakehiscore"
+)
+
+func TestTotalKCForActivityListsStaleAccounts(t *testing.T) {
+	participant := Participant{
+		DiscordId: "1",
+		LinkedOSRSAccounts: map[string]OSRSAccount{
+			"main": {
+				Name:       "Main",
+				Activities: map[string]OSRSActivity{"Zulrah": {StartAmount: 10, CurrentAmount: 40}},
+			},
+			"gone": {
+				Name:       "Gone",
+				Stale:      true,
+				Activities: map[string]OSRSActivity{"Zulrah": {StartAmount: 5, CurrentAmount: 5}},
+			},
+			"idle": {
+				Name:       "Idle",
+				Activities: map[string]OSRSActivity{"Zulrah": {StartAmount: 5, CurrentAmount: 5}},
+			},
+		},
+	}
+
+	total, accounts := participant.TotalKCForActivity("Zulrah")
+	if total != 30 {
+		t.Errorf("total KC = %d, want 30", total)
+	}
+	if len(accounts) != 2 || accounts[0].AccountName != "Main" || accounts[1].AccountName != "Gone" || !accounts[1].Stale {
+		t.Errorf("accounts = %+v, want Main and the stale Gone", accounts)
+	}
+}
+
+func TestConcurrentTrackingKeepsEveryAccount(t *testing.T) {
+	useTestAssets(t)
+	players := map[string][]fakehiscore.Step{}
+	for n := range 20 {
+		players[fmt.Sprintf("Player%d", n)] = []fakehiscore.Step{{At: 0, Activities: zulrah(n)}}
+	}
+	useFakeHiscores(t, fakehiscore.Fixture{Players: players})
+
+	const guildID = "guild"
+	var wg sync.WaitGroup
+	for name := range players {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := TrackAccount(guildID, name, name); err != nil {
+				t.Errorf("TrackAccount(%s): %v", name, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(participants) != len(players) {
+		t.Errorf("%d of %d accounts were saved", len(participants), len(players))
+	}
+}
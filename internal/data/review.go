@@ -0,0 +1,63 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// KCReview is a KC change that looked implausible and waits for an admin.
+type KCReview struct {
+	ID             string    `json:"id"`
+	DiscordId      string    `json:"discordId"`
+	AccountKey     string    `json:"accountKey"`
+	AccountName    string    `json:"accountName"`
+	Activity       string    `json:"activity"`
+	PreviousAmount int       `json:"previousAmount"`
+	ReportedAmount int       `json:"reportedAmount"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Change is the KC difference the hiscores reported.
+func (r KCReview) Change() int {
+	return r.ReportedAmount - r.PreviousAmount
+}
+
+const reviewsFilePath = "%s_reviews.json"
+
+func getKCReviews(guildID string) ([]KCReview, error) {
+	data, err := os.ReadFile(assetPath(reviewsFilePath, guildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var reviews []KCReview
+	err = json.Unmarshal(data, &reviews)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return reviews, nil
+}
+
+func saveKCReviews(guildID string, reviews []KCReview) error {
+	data, err := json.MarshalIndent(reviews, "", "  ") // Pretty-print
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviews: %w", err)
+	}
+
+	err = os.WriteFile(assetPath(reviewsFilePath, guildID), data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	return nil
+}
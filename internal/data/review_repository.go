@@ -0,0 +1,190 @@
+package data
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"misclicked-events/internal/constants"
+	"slices"
+	"time"
+)
+
+// isImplausibleChange reports whether going from the activity's current KC to
+// kc needs a second look: it can't go up faster than the activity's MaxPerHour
+// allows for the time since the KC last changed. KC going down, after a
+// rollback or a rename, never adds to a score, so it doesn't need a look.
+func isImplausibleChange(activity OSRSActivity, kc int, activityName string) bool {
+	change := kc - activity.CurrentAmount
+	if change <= 0 {
+		return false
+	}
+
+	maxPerHour := constants.Activities[activityName].MaxPerHour
+	if maxPerHour <= 0 {
+		return false
+	}
+
+	// Accounts saved before ChangedAt existed only know their last update
+	since := activity.ChangedAt
+	if since.IsZero() {
+		since = activity.UpdatedAt
+	}
+
+	hours := 1.0
+	if !since.IsZero() {
+		hours = max(1, math.Ceil(time.Since(since).Hours()))
+	}
+
+	return float64(change) > float64(maxPerHour)*hours
+}
+
+// ErrReviewHandled is returned when another admin settled the review already.
+var ErrReviewHandled = errors.New("this review has already been handled")
+
+// PendingReviewsError is returned when an event can't end because KC changes
+// are still waiting for an admin, they decide the final standings.
+type PendingReviewsError struct {
+	Reviews []KCReview
+}
+
+func (e *PendingReviewsError) Error() string {
+	return fmt.Sprintf("%d KC changes are waiting for review", len(e.Reviews))
+}
+
+// PendingKCReviews returns every KC change still waiting for an admin, oldest first.
+func PendingKCReviews(guildID string) ([]KCReview, error) {
+	reviews, err := getKCReviews(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch KC reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// canReviewKC reports whether the guild has an admin channel to ask about held
+// KC changes in. Without one nobody would hear of them, so changes count right
+// away instead of waiting for a review that never comes.
+func canReviewKC(guildID string) bool {
+	config, err := GetBotConfig(guildID)
+	return err == nil && config.AdminChannelID != ""
+}
+
+// pendingKCReviews returns the reviews still waiting for an admin for the activity.
+func pendingKCReviews(guildID, activityName string) ([]KCReview, error) {
+	reviews, err := getKCReviews(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(reviews, func(r KCReview) bool {
+		return r.Activity != activityName
+	}), nil
+}
+
+func hasPendingReview(reviews []KCReview, discordId, accountKey, activityName string) bool {
+	return slices.ContainsFunc(reviews, func(r KCReview) bool {
+		return r.DiscordId == discordId && r.AccountKey == accountKey && r.Activity == activityName
+	})
+}
+
+func newKCReview(discordId, accountKey, accountName string, activity OSRSActivity, kc int) (KCReview, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return KCReview{}, fmt.Errorf("failed to generate review id: %w", err)
+	}
+
+	return KCReview{
+		ID:             hex.EncodeToString(id),
+		DiscordId:      discordId,
+		AccountKey:     accountKey,
+		AccountName:    accountName,
+		Activity:       activity.Name,
+		PreviousAmount: activity.CurrentAmount,
+		ReportedAmount: kc,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// ResolveKCReview settles a held KC change. Approving applies the reported
+// KC, rejecting moves the account past it without counting the change, so
+// later gains count as usual either way.
+func ResolveKCReview(guildID, reviewID string, approve bool) (KCReview, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	reviews, err := getKCReviews(guildID)
+	if err != nil {
+		return KCReview{}, fmt.Errorf("failed to fetch KC reviews: %w", err)
+	}
+
+	index := slices.IndexFunc(reviews, func(r KCReview) bool {
+		return r.ID == reviewID
+	})
+	if index < 0 {
+		return KCReview{}, ErrReviewHandled
+	}
+	review := reviews[index]
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		return review, fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	participant, ok := participants[review.DiscordId]
+	if ok {
+		if account, ok := participant.LinkedOSRSAccounts[review.AccountKey]; ok {
+			if activity, ok := account.Activities[review.Activity]; ok {
+				if !approve {
+					activity.StartAmount += review.ReportedAmount - activity.CurrentAmount
+				}
+				activity.CurrentAmount = review.ReportedAmount
+				activity.UpdatedAt = review.CreatedAt
+				activity.ChangedAt = review.CreatedAt
+				account.Activities[review.Activity] = activity
+				participant.LinkedOSRSAccounts[review.AccountKey] = account
+				participants[review.DiscordId] = participant
+
+				err = saveParticipantsData(guildID, participants)
+				if err != nil {
+					return review, fmt.Errorf("failed to save participants: %w", err)
+				}
+			}
+		}
+	}
+
+	// The review is done with even when the account was untracked in the meantime
+	reviews = slices.Delete(reviews, index, index+1)
+	err = saveKCReviews(guildID, reviews)
+	if err != nil {
+		return review, fmt.Errorf("failed to save KC reviews: %w", err)
+	}
+
+	return review, nil
+}
+
+// transferKCReviews hands the pending reviews of an account to its new owner.
+func transferKCReviews(guildID, accountKey, fromDiscordId, toDiscordId string) error {
+	reviews, err := getKCReviews(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch KC reviews: %w", err)
+	}
+
+	changed := false
+	for i, review := range reviews {
+		if review.DiscordId == fromDiscordId && review.AccountKey == accountKey {
+			reviews[i].DiscordId = toDiscordId
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	err = saveKCReviews(guildID, reviews)
+	if err != nil {
+		return fmt.Errorf("failed to save KC reviews: %w", err)
+	}
+
+	return nil
+}
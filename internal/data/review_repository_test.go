@@ -0,0 +1,118 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"misclicked-events/internal/fakehiscore"
+)
+
+func TestIsImplausibleChange(t *testing.T) {
+	// Zulrah allows 40 KC an hour
+	tests := []struct {
+		name     string
+		changed  time.Duration // ago, 0 for never
+		updated  time.Duration // ago, 0 for never
+		current  int
+		kc       int
+		activity string
+		want     bool
+	}{
+		{"unchanged", time.Hour, time.Hour, 100, 100, "Zulrah", false},
+		{"rollback", time.Hour, time.Hour, 100, 99, "Zulrah", false},
+		{"within an hour", 30 * time.Minute, 30 * time.Minute, 100, 140, "Zulrah", false},
+		{"too fast for an hour", 30 * time.Minute, 30 * time.Minute, 100, 141, "Zulrah", true},
+		{"just changed counts as an hour", time.Minute, time.Minute, 100, 140, "Zulrah", false},
+		{"partial hours round up", 90 * time.Minute, 90 * time.Minute, 100, 180, "Zulrah", false},
+		{"too fast over hours", 179 * time.Minute, 179 * time.Minute, 100, 221, "Zulrah", true},
+		// Polled every hour, but the four hour session only showed at log out
+		{"session since the last change", 239 * time.Minute, 30 * time.Minute, 100, 220, "Zulrah", false},
+		{"too fast for the session", 239 * time.Minute, 30 * time.Minute, 100, 261, "Zulrah", true},
+		{"saved before changes were tracked", 0, 179 * time.Minute, 100, 220, "Zulrah", false},
+		{"never updated", 0, 0, 100, 140, "Zulrah", false},
+		{"no limit for unknown activity", time.Hour, time.Hour, 100, 10000, "Unknown", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := OSRSActivity{Name: tt.activity, CurrentAmount: tt.current}
+			if tt.changed > 0 {
+				activity.ChangedAt = time.Now().Add(-tt.changed)
+			}
+			if tt.updated > 0 {
+				activity.UpdatedAt = time.Now().Add(-tt.updated)
+			}
+
+			if got := isImplausibleChange(activity, tt.kc, tt.activity); got != tt.want {
+				t.Errorf("isImplausibleChange(%d -> %d) = %v, want %v", tt.current, tt.kc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKCFollowsTheHiscoresDown(t *testing.T) {
+	useTestAssets(t)
+	srv := useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {
+				{At: 0, Activities: zulrah(100)},
+				{At: fakehiscore.Duration(time.Hour), Activities: zulrah(110)},
+				// Jagex rolled back some kills
+				{At: fakehiscore.Duration(2 * time.Hour), Activities: zulrah(105)},
+			},
+			"Alt": {{At: 0, Activities: zulrah(20)}},
+		},
+	})
+
+	const guildID = "guild"
+	if err := SaveBotConfig(guildID, BotConfig{AdminChannelID: "admins"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := TrackAccount(guildID, "Alpha", "1"); err != nil {
+		t.Fatalf("TrackAccount: %v", err)
+	}
+	if err := StartCompetition(guildID, "Zulrah", ""); err != nil {
+		t.Fatalf("StartCompetition: %v", err)
+	}
+
+	kc := func() int {
+		t.Helper()
+		participants, err := getParticipants(guildID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return participants["1"].LinkedOSRSAccounts["alpha"].KCForActivity("Zulrah")
+	}
+	update := func() {
+		t.Helper()
+		report, err := UpdateAccountsKC(guildID)
+		if err != nil {
+			t.Fatalf("UpdateAccountsKC: %v", err)
+		}
+		if len(report.NewReviews) > 0 {
+			t.Fatalf("held %+v for review", report.NewReviews)
+		}
+	}
+
+	srv.Advance(time.Hour)
+	update()
+	srv.Advance(time.Hour)
+	update()
+	if got := kc(); got != 5 {
+		t.Errorf("KC after the rollback = %d, want 5", got)
+	}
+
+	// The wrong account was renamed to, its KC is below where the event started
+	if err := RenameAccount(guildID, "Alpha", "Alt", "1"); err != nil {
+		t.Fatalf("RenameAccount: %v", err)
+	}
+	update()
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := participants["1"].LinkedOSRSAccounts["alt"].KCForActivity("Zulrah"); got != 0 {
+		t.Errorf("KC after renaming to an account with less KC = %d, want 0", got)
+	}
+}
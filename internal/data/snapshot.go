@@ -0,0 +1,73 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// StandingsSnapshot is what the current event's leaderboard looked like
+// right after a KC update.
+type StandingsSnapshot struct {
+	Activity     string          `json:"activity"`
+	TakenAt      time.Time       `json:"takenAt"`
+	Participants []SnapshotEntry `json:"participants"`
+}
+
+// SnapshotEntry is a participant in a snapshot. Rank is 0 below the threshold.
+type SnapshotEntry struct {
+	DiscordId string         `json:"discordId"`
+	Rank      int            `json:"rank,omitempty"`
+	TotalKC   int            `json:"totalKc"`
+	Accounts  map[string]int `json:"accounts"`
+}
+
+// Entry looks up a participant in the snapshot.
+func (s StandingsSnapshot) Entry(discordId string) (SnapshotEntry, bool) {
+	for _, entry := range s.Participants {
+		if entry.DiscordId == discordId {
+			return entry, true
+		}
+	}
+	return SnapshotEntry{}, false
+}
+
+const snapshotsFilePath = "%s_snapshots.json"
+
+// GetStandingsSnapshots returns the snapshots of the current event, oldest first.
+func GetStandingsSnapshots(guildID string) ([]StandingsSnapshot, error) {
+	data, err := os.ReadFile(assetPath(snapshotsFilePath, guildID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var snapshots []StandingsSnapshot
+	err = json.Unmarshal(data, &snapshots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func saveStandingsSnapshots(guildID string, snapshots []StandingsSnapshot) error {
+	data, err := json.Marshal(snapshots) // Not pretty-printed, this file grows every hour
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshots: %w", err)
+	}
+
+	err = os.WriteFile(assetPath(snapshotsFilePath, guildID), data, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write to file: %w", err)
+	}
+
+	return nil
+}
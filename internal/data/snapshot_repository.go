@@ -0,0 +1,192 @@
+package data
+
+import (
+	"fmt"
+	"misclicked-events/internal/constants"
+	"sort"
+	"time"
+)
+
+// standingsWindow is how far back the leaderboard looks for the KC gained.
+const standingsWindow = 24 * time.Hour
+
+// RecordStandingsSnapshot saves the current standings of the running event,
+// it's meant to be called right after every KC update.
+func RecordStandingsSnapshot(guildID string) error {
+	activity := GetCurrentBoss(guildID)
+	if activity == "" {
+		return fmt.Errorf("no event found")
+	}
+
+	participantKC, err := GetParticipantsByActivityKC(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get participants: %w", err)
+	}
+
+	snapshot := StandingsSnapshot{
+		Activity: activity,
+		TakenAt:  time.Now(),
+	}
+
+	threshold := constants.Activities[activity].Threshold
+	rank := 0
+	previousKC := -1
+	for i, participant := range participantKC {
+		entry := SnapshotEntry{
+			DiscordId: participant.DiscordId,
+			TotalKC:   participant.TotalKC,
+			Accounts:  map[string]int{},
+		}
+
+		if participant.TotalKC >= threshold {
+			if participant.TotalKC != previousKC {
+				rank = i + 1
+			}
+			previousKC = participant.TotalKC
+			entry.Rank = rank
+		}
+
+		for _, account := range participant.AccountKCs {
+			entry.Accounts[account.AccountName] = account.TotalKC
+		}
+
+		snapshot.Participants = append(snapshot.Participants, entry)
+	}
+
+	snapshots, err := GetStandingsSnapshots(guildID)
+	if err != nil {
+		return err
+	}
+
+	// Snapshots of an earlier event don't mean anything for this one
+	if len(snapshots) > 0 && snapshots[0].Activity != activity {
+		snapshots = nil
+	}
+
+	snapshots = pruneStandingsSnapshots(append(snapshots, snapshot), snapshot.TakenAt)
+	return saveStandingsSnapshots(guildID, snapshots)
+}
+
+// pruneStandingsSnapshots drops the snapshots nothing reads anymore. The
+// leaderboard needs every snapshot of the last standingsWindow and the newest
+// one before it, older ones only draw the charts, where one a day is enough.
+func pruneStandingsSnapshots(snapshots []StandingsSnapshot, now time.Time) []StandingsSnapshot {
+	cutoff := now.Add(-standingsWindow)
+
+	var kept []StandingsSnapshot
+	var lastDaily time.Time
+	for i, snapshot := range snapshots {
+		if snapshot.TakenAt.After(cutoff) {
+			kept = append(kept, snapshot)
+			continue
+		}
+
+		newestBeforeCutoff := i+1 == len(snapshots) || snapshots[i+1].TakenAt.After(cutoff)
+		if i == 0 || newestBeforeCutoff || snapshot.TakenAt.Sub(lastDaily) >= standingsWindow {
+			kept = append(kept, snapshot)
+			lastDaily = snapshot.TakenAt
+		}
+	}
+
+	return kept
+}
+
+// PreviousStandings returns the snapshot from before the latest update and
+// the most recent one that is at least 24 hours old, which falls back to the
+// oldest snapshot while the event is younger than that. Either can be nil
+// when there aren't enough snapshots yet.
+func PreviousStandings(guildID string) (*StandingsSnapshot, *StandingsSnapshot, error) {
+	snapshots, err := GetStandingsSnapshots(guildID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	activity := GetCurrentBoss(guildID)
+	if len(snapshots) < 2 || snapshots[0].Activity != activity {
+		return nil, nil, nil
+	}
+
+	previous := &snapshots[len(snapshots)-2]
+
+	dayAgo := &snapshots[0]
+	cutoff := time.Now().Add(-standingsWindow)
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if !snapshots[i].TakenAt.After(cutoff) {
+			dayAgo = &snapshots[i]
+			break
+		}
+	}
+
+	return previous, dayAgo, nil
+}
+
+func clearStandingsSnapshots(guildID string) error {
+	return saveStandingsSnapshots(guildID, nil)
+}
+
+// KCSeries is the KC of a participant or an account at every snapshot.
+type KCSeries struct {
+	// Key is the Discord ID of the participant or the name of the account.
+	Key    string
+	Points []KCPoint
+}
+
+type KCPoint struct {
+	At time.Time
+	KC int
+}
+
+// ParticipantKCSeries returns the KC over the event of the top n participants
+// of the latest snapshot, highest first. Participants that joined later start
+// at 0.
+func ParticipantKCSeries(snapshots []StandingsSnapshot, n int) []KCSeries {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	latest := snapshots[len(snapshots)-1].Participants
+	var series []KCSeries
+	for _, participant := range latest[:min(n, len(latest))] {
+		line := KCSeries{Key: participant.DiscordId}
+		for _, snapshot := range snapshots {
+			entry, _ := snapshot.Entry(participant.DiscordId)
+			line.Points = append(line.Points, KCPoint{At: snapshot.TakenAt, KC: entry.TotalKC})
+		}
+		series = append(series, line)
+	}
+
+	return series
+}
+
+// AccountKCSeries returns the KC over the event of each account the
+// participant has in the latest snapshot, highest first.
+func AccountKCSeries(snapshots []StandingsSnapshot, discordId string) []KCSeries {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	latest, ok := snapshots[len(snapshots)-1].Entry(discordId)
+	if !ok {
+		return nil
+	}
+
+	var series []KCSeries
+	for accountName := range latest.Accounts {
+		line := KCSeries{Key: accountName}
+		for _, snapshot := range snapshots {
+			entry, _ := snapshot.Entry(discordId)
+			line.Points = append(line.Points, KCPoint{At: snapshot.TakenAt, KC: entry.Accounts[accountName]})
+		}
+		series = append(series, line)
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		a, b := latest.Accounts[series[i].Key], latest.Accounts[series[j].Key]
+		if a != b {
+			return a > b
+		}
+		return series[i].Key < series[j].Key
+	})
+
+	return series
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPruneStandingsSnapshots(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Three days of hourly updates, pruned after each like the updates do
+	var snapshots []StandingsSnapshot
+	var now time.Time
+	for hour := range 72 {
+		now = start.Add(time.Duration(hour) * time.Hour)
+		snapshots = pruneStandingsSnapshots(append(snapshots, StandingsSnapshot{TakenAt: now}), now)
+	}
+
+	if !snapshots[0].TakenAt.Equal(start) {
+		t.Errorf("first snapshot at %s, want the start of the event kept", snapshots[0].TakenAt)
+	}
+
+	cutoff := now.Add(-standingsWindow)
+	recent := 0
+	for i, snapshot := range snapshots {
+		if snapshot.TakenAt.After(cutoff) {
+			recent++
+			continue
+		}
+		if i > 0 && snapshots[i+1].TakenAt.Before(cutoff) && snapshot.TakenAt.Sub(snapshots[i-1].TakenAt) < standingsWindow {
+			t.Errorf("kept %s, less than a day after the one before it", snapshot.TakenAt)
+		}
+	}
+	if recent != 24 {
+		t.Errorf("kept %d snapshots of the last day, want all 24", recent)
+	}
+	if dayAgo := snapshots[len(snapshots)-recent-1]; !dayAgo.TakenAt.Equal(cutoff) {
+		t.Errorf("newest snapshot before the last day is from %s, want %s", dayAgo.TakenAt, cutoff)
+	}
+	if len(snapshots) > recent+4 {
+		t.Errorf("kept %d snapshots, want the older ones thinned to one a day", len(snapshots))
+	}
+}
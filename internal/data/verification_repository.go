@@ -0,0 +1,280 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"misclicked-events/internal/service"
+	"slices"
+	"time"
+
+	"golang.org/x/text/cases"
+)
+
+const (
+	// VerificationChallengeDuration is how long a member has to gain the XP.
+	VerificationChallengeDuration = 30 * time.Minute
+	// VerificationRetryCooldown is how long after a challenge ran out the
+	// member has to wait for a new one, so nobody can keep rerolling until
+	// they get a skill the account is trained in anyway.
+	VerificationRetryCooldown = time.Hour
+)
+
+// A challenge asks for a random amount of XP in this range, in steps of 10.
+const (
+	minVerificationXP = 50
+	maxVerificationXP = 150
+)
+
+// verificationSkills are non-combat skills that can be trained anywhere
+// without unlocking anything, so every account can pass a challenge in them
+// and nobody gains the XP by accident while fighting.
+var verificationSkills = []string{
+	"Cooking", "Woodcutting", "Fishing", "Firemaking", "Crafting", "Mining",
+}
+
+var (
+	ErrAlreadyVerified  = errors.New("account already verified")
+	ErrChallengeExpired = errors.New("challenge expired")
+	// ErrNoRankedSkill is returned when the hiscores don't list the account
+	// in any verification skill, XP gained in one wouldn't show up.
+	ErrNoRankedSkill = errors.New("no verification skill is on the hiscores")
+)
+
+// ChallengeCooldownError is returned when a new challenge is asked for too
+// soon after the last one ran out.
+type ChallengeCooldownError struct {
+	RetryAt time.Time
+}
+
+func (e *ChallengeCooldownError) Error() string {
+	return fmt.Sprintf("a new challenge can be started at %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// VerificationChallenge asks the owner of an account to gain at least XP in
+// Skill before ExpiresAt, proving they can log in to it.
+type VerificationChallenge struct {
+	Skill     string    `json:"skill"`
+	StartXP   int       `json:"startXp"`
+	XP        int       `json:"xp,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// IsVerificationRequired reports whether the guild wants accounts verified
+// before their KC counts.
+func IsVerificationRequired(guildID string) bool {
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return false
+	}
+	return config.RequireVerification
+}
+
+// SetVerificationRequired turns verification mode on or off. Accounts that are
+// already tracked count as verified. Turning it off verifies the accounts only
+// one member tracks, and takes unverified copies away from members when
+// someone else verified the account. Accounts several members track without
+// anyone having verified them stay unverified until one of them does.
+func SetVerificationRequired(guildID string, required bool) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	config.RequireVerification = required
+	err = SaveBotConfig(guildID, *config)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		return nil
+	}
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch participants: %w", err)
+	}
+
+	holders := make(map[string]int)
+	for _, participant := range participants {
+		for key := range participant.LinkedOSRSAccounts {
+			holders[key]++
+		}
+	}
+
+	for discordId, participant := range participants {
+		for key, account := range participant.LinkedOSRSAccounts {
+			if account.Unverified && holders[key] == 1 {
+				account.Unverified = false
+				account.Challenge = nil
+				participant.LinkedOSRSAccounts[key] = account
+			}
+		}
+		participants[discordId] = participant
+	}
+
+	for _, participant := range slices.Collect(maps.Values(participants)) {
+		for _, account := range participant.LinkedOSRSAccounts {
+			if !account.Unverified {
+				releaseUnverifiedCopies(participants, account.Name, participant.DiscordId)
+			}
+		}
+	}
+
+	return saveParticipantsData(guildID, participants)
+}
+
+// StartVerification gives the member a challenge for their unverified
+// account. A challenge that is still running is returned as it is, a new one
+// is only handed out VerificationRetryCooldown after the last one ended.
+func StartVerification(guildID, username, discordId string) (VerificationChallenge, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	participants, account, err := unverifiedAccount(guildID, username, discordId)
+	if err != nil {
+		return VerificationChallenge{}, err
+	}
+
+	if previous := account.Challenge; previous != nil {
+		if time.Now().Before(previous.ExpiresAt) {
+			return *previous, nil
+		}
+		if retryAt := previous.ExpiresAt.Add(VerificationRetryCooldown); time.Now().Before(retryAt) {
+			return VerificationChallenge{}, &ChallengeCooldownError{RetryAt: retryAt}
+		}
+	}
+
+	skills, _, err := service.FetchHiscore(account.Name)
+	if err != nil {
+		return VerificationChallenge{}, fmt.Errorf("failed to fetch hiscores: %w", err)
+	}
+
+	skillName, ok := pickVerificationSkill(skills)
+	if !ok {
+		return VerificationChallenge{}, ErrNoRankedSkill
+	}
+	challenge := VerificationChallenge{
+		Skill:     skillName,
+		StartXP:   skillXP(skills, skillName),
+		XP:        minVerificationXP + 10*rand.IntN((maxVerificationXP-minVerificationXP)/10+1),
+		ExpiresAt: time.Now().Add(VerificationChallengeDuration),
+	}
+
+	account.Challenge = &challenge
+	saveAccount(participants, discordId, account)
+
+	err = saveParticipantsData(guildID, participants)
+	if err != nil {
+		return VerificationChallenge{}, fmt.Errorf("failed to save participants: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// CheckVerification looks up whether the member gained the XP the challenge
+// asks for, and verifies the account when they did. It returns the pending
+// challenge, nil when the account has none.
+func CheckVerification(guildID, username, discordId string) (bool, *VerificationChallenge, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	participants, account, err := unverifiedAccount(guildID, username, discordId)
+	if err != nil {
+		return false, nil, err
+	}
+
+	challenge := account.Challenge
+	if challenge == nil {
+		return false, nil, nil
+	}
+	if time.Now().After(challenge.ExpiresAt) {
+		return false, challenge, ErrChallengeExpired
+	}
+
+	skills, _, err := service.FetchHiscore(account.Name)
+	if err != nil {
+		return false, challenge, fmt.Errorf("failed to fetch hiscores: %w", err)
+	}
+
+	gained := skillXP(skills, challenge.Skill) - challenge.StartXP
+	if gained <= 0 || gained < challenge.XP {
+		return false, challenge, nil
+	}
+
+	account.Unverified = false
+	account.Challenge = nil
+	saveAccount(participants, discordId, account)
+	releaseUnverifiedCopies(participants, account.Name, discordId)
+
+	err = saveParticipantsData(guildID, participants)
+	if err != nil {
+		return false, challenge, fmt.Errorf("failed to save participants: %w", err)
+	}
+
+	return true, challenge, nil
+}
+
+func unverifiedAccount(guildID, username, discordId string) (map[string]Participant, OSRSAccount, error) {
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		return nil, OSRSAccount{}, err
+	}
+
+	participant, ok := participants[discordId]
+	if !ok {
+		return nil, OSRSAccount{}, ErrNoTrackedAccounts
+	}
+
+	account, ok := participant.LinkedOSRSAccounts[cases.Fold().String(username)]
+	if !ok {
+		return nil, OSRSAccount{}, ErrAccountNotFound
+	}
+	if !account.Unverified {
+		return nil, account, ErrAlreadyVerified
+	}
+
+	return participants, account, nil
+}
+
+func saveAccount(participants map[string]Participant, discordId string, account OSRSAccount) {
+	participant := participants[discordId]
+	participant.LinkedOSRSAccounts[cases.Fold().String(account.Name)] = account
+	participants[discordId] = participant
+}
+
+// VerificationSkills returns the skills challenges can ask for.
+func VerificationSkills() []string {
+	return slices.Clone(verificationSkills)
+}
+
+// pickVerificationSkill picks a random verification skill the hiscores rank
+// the account in, XP gained in an unranked skill never shows up. It reports
+// false when the account isn't ranked in any of them.
+func pickVerificationSkill(skills []service.Skill) (string, bool) {
+	var ranked []string
+	for _, name := range verificationSkills {
+		if skill, ok := service.FindSkill(skills, name); ok && skill.XP >= 0 {
+			ranked = append(ranked, name)
+		}
+	}
+	if len(ranked) == 0 {
+		return "", false
+	}
+	return ranked[rand.IntN(len(ranked))], true
+}
+
+// skillXP returns the XP the hiscores list for the skill, unranked skills
+// count as 0.
+func skillXP(skills []service.Skill, name string) int {
+	skill, ok := service.FindSkill(skills, name)
+	if !ok || skill.XP < 0 {
+		return 0
+	}
+	return skill.XP
+}
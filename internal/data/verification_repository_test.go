@@ -0,0 +1,173 @@
+package data
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"misclicked-events/internal/fakehiscore"
+	"misclicked-events/internal/service"
+)
+
+// verificationSkillXP gives every verification skill the XP.
+func verificationSkillXP(xp int) map[string]int {
+	skills := make(map[string]int)
+	for _, skill := range verificationSkills {
+		skills[skill] = xp
+	}
+	return skills
+}
+
+// verifyingAccount tracks Alpha for member 1 in a guild that requires
+// verification, with the fixture's skills starting at 100k XP each.
+func verifyingAccount(t *testing.T, gained int) *fakehiscore.Server {
+	t.Helper()
+	useTestAssets(t)
+	srv := useFakeHiscores(t, fakehiscore.Fixture{
+		Players: map[string][]fakehiscore.Step{
+			"Alpha": {
+				{At: 0, Skills: verificationSkillXP(100_000)},
+				{At: fakehiscore.Duration(time.Minute), Skills: verificationSkillXP(100_000 + gained)},
+			},
+		},
+	})
+
+	if err := SaveBotConfig("guild", BotConfig{RequireVerification: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := TrackAccount("guild", "Alpha", "1"); err != nil {
+		t.Fatalf("TrackAccount: %v", err)
+	}
+	return srv
+}
+
+func TestVerificationChallenge(t *testing.T) {
+	srv := verifyingAccount(t, 0)
+
+	challenge, err := StartVerification("guild", "Alpha", "1")
+	if err != nil {
+		t.Fatalf("StartVerification: %v", err)
+	}
+	if !slices.Contains(verificationSkills, challenge.Skill) {
+		t.Errorf("challenge in %s, want a non-combat skill", challenge.Skill)
+	}
+	if challenge.XP < minVerificationXP || challenge.XP > maxVerificationXP || challenge.XP%10 != 0 {
+		t.Errorf("challenge asks for %d XP", challenge.XP)
+	}
+
+	// Asking again while it runs doesn't reroll the skill
+	again, err := StartVerification("guild", "Alpha", "1")
+	if err != nil || again.Skill != challenge.Skill || again.XP != challenge.XP || !again.ExpiresAt.Equal(challenge.ExpiresAt) {
+		t.Errorf("StartVerification again = %+v, %v, want the running challenge %+v", again, err, challenge)
+	}
+
+	srv.Advance(time.Minute)
+	verified, _, err := CheckVerification("guild", "Alpha", "1")
+	if err != nil || verified {
+		t.Errorf("CheckVerification without new XP = %v, %v", verified, err)
+	}
+}
+
+func TestVerificationAcceptsAnyGainOfTheXP(t *testing.T) {
+	// A single log burns more than any challenge asks for
+	srv := verifyingAccount(t, 5_000)
+
+	if _, err := StartVerification("guild", "Alpha", "1"); err != nil {
+		t.Fatalf("StartVerification: %v", err)
+	}
+	srv.Advance(time.Minute)
+
+	verified, _, err := CheckVerification("guild", "Alpha", "1")
+	if err != nil || !verified {
+		t.Errorf("CheckVerification = %v, %v, want verified", verified, err)
+	}
+}
+
+func TestVerificationCooldownAfterExpiry(t *testing.T) {
+	verifyingAccount(t, 0)
+
+	if _, err := StartVerification("guild", "Alpha", "1"); err != nil {
+		t.Fatalf("StartVerification: %v", err)
+	}
+
+	participants, err := getParticipants("guild")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := participants["1"].LinkedOSRSAccounts["alpha"]
+	account.Challenge.ExpiresAt = time.Now().Add(-time.Minute)
+	saveAccount(participants, "1", account)
+	if err := saveParticipantsData("guild", participants); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := CheckVerification("guild", "Alpha", "1"); !errors.Is(err, ErrChallengeExpired) {
+		t.Errorf("CheckVerification = %v, want expired", err)
+	}
+
+	// The expired challenge can't be swapped for a fresh one straight away
+	var cooldown *ChallengeCooldownError
+	_, err = StartVerification("guild", "Alpha", "1")
+	if !errors.As(err, &cooldown) || time.Until(cooldown.RetryAt) < VerificationRetryCooldown-2*time.Minute {
+		t.Errorf("StartVerification after expiry = %v, want a cooldown of about %s", err, VerificationRetryCooldown)
+	}
+}
+
+func TestPickVerificationSkillOnlyPicksRankedSkills(t *testing.T) {
+	skills := []service.Skill{{Name: "Cooking", XP: -1}, {Name: "Mining", XP: 500}}
+	for range 20 {
+		if skill, ok := pickVerificationSkill(skills); !ok || skill != "Mining" {
+			t.Fatalf("picked %s, %v, want the only ranked skill Mining", skill, ok)
+		}
+	}
+
+	if skill, ok := pickVerificationSkill([]service.Skill{{Name: "Cooking", XP: -1}}); ok {
+		t.Errorf("picked %s without a ranked skill", skill)
+	}
+}
+
+func TestTurningVerificationOffKeepsContestedAccountsUnverified(t *testing.T) {
+	useTestAssets(t)
+
+	const guildID = "guild"
+	if err := SaveBotConfig(guildID, BotConfig{RequireVerification: true}); err != nil {
+		t.Fatal(err)
+	}
+	participants := map[string]Participant{
+		"1": {DiscordId: "1", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"alpha": {Name: "Alpha"},
+			"bravo": {Name: "Bravo", Unverified: true},
+		}},
+		"2": {DiscordId: "2", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"alpha":   {Name: "Alpha", Unverified: true},
+			"charlie": {Name: "Charlie", Unverified: true},
+		}},
+		"3": {DiscordId: "3", LinkedOSRSAccounts: map[string]OSRSAccount{
+			"charlie": {Name: "Charlie", Unverified: true},
+		}},
+	}
+	if err := saveParticipantsData(guildID, participants); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetVerificationRequired(guildID, false); err != nil {
+		t.Fatalf("SetVerificationRequired: %v", err)
+	}
+
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := participants["2"].LinkedOSRSAccounts["alpha"]; ok {
+		t.Error("member 2 kept the copy of Alpha member 1 verified")
+	}
+	if participants["1"].LinkedOSRSAccounts["bravo"].Unverified {
+		t.Error("Bravo only member 1 tracks is still unverified")
+	}
+	for _, discordId := range []string{"2", "3"} {
+		if account, ok := participants[discordId].LinkedOSRSAccounts["charlie"]; !ok || !account.Unverified {
+			t.Errorf("member %s: Charlie = %+v, %v, want it kept unverified", discordId, account, ok)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"misclicked-events/internal/utils"
+	"misclicked-events/internal/webhook"
+	"slices"
+	"time"
+)
+
+// The events sent to webhooks.
+const (
+	WebhookCompetitionStarted = "competition.started"
+	WebhookCompetitionUpdated = "competition.updated"
+	WebhookCompetitionEnded   = "competition.ended"
+	WebhookPointsAwarded      = "points.awarded"
+)
+
+// MaxWebhooks is how many webhooks a guild can have.
+const MaxWebhooks = 5
+
+var (
+	ErrWebhookExists   = errors.New("webhook already exists")
+	ErrWebhookNotFound = errors.New("webhook not found")
+	ErrTooManyWebhooks = errors.New("too many webhooks")
+)
+
+// WebhookConfig is a URL the guild gets competition events on. Deliveries
+// are signed with the secret.
+type WebhookConfig struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+// CompetitionStartedEvent is the data of competition.started.
+type CompetitionStartedEvent struct {
+	Activity  string    `json:"activity"`
+	Threshold int       `json:"threshold"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// PointsAwardedEvent is the data of points.awarded.
+type PointsAwardedEvent struct {
+	Activity string        `json:"activity"`
+	Awards   []PointsAward `json:"awards"`
+}
+
+// PointsAward is what a participant earned, TotalPoints includes it.
+type PointsAward struct {
+	DiscordId   string `json:"discordId"`
+	Rank        int    `json:"rank"`
+	Points      int    `json:"points"`
+	TotalPoints int    `json:"totalPoints"`
+}
+
+// AddWebhook registers a URL for the guild's events and returns the secret
+// deliveries to it are signed with.
+func AddWebhook(guildID, url string) (string, error) {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	if slices.ContainsFunc(config.Webhooks, func(hook WebhookConfig) bool { return hook.URL == url }) {
+		return "", ErrWebhookExists
+	}
+	if len(config.Webhooks) >= MaxWebhooks {
+		return "", ErrTooManyWebhooks
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return "", err
+	}
+
+	config.Webhooks = append(config.Webhooks, WebhookConfig{URL: url, Secret: secret})
+	return secret, SaveBotConfig(guildID, *config)
+}
+
+// RemoveWebhook stops sending the guild's events to the URL.
+func RemoveWebhook(guildID, url string) error {
+	unlock := guildLocks.Lock(guildID)
+	defer unlock()
+
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return fmt.Errorf("failed to get bot config: %w", err)
+	}
+
+	index := slices.IndexFunc(config.Webhooks, func(hook WebhookConfig) bool { return hook.URL == url })
+	if index < 0 {
+		return ErrWebhookNotFound
+	}
+
+	config.Webhooks = slices.Delete(config.Webhooks, index, index+1)
+	return SaveBotConfig(guildID, *config)
+}
+
+// GetWebhooks returns the webhooks of the guild, none when it isn't set up.
+func GetWebhooks(guildID string) []WebhookConfig {
+	config, err := GetBotConfig(guildID)
+	if err != nil {
+		return nil
+	}
+	return config.Webhooks
+}
+
+// SendWebhookEvent delivers the event to the guild's webhooks in the background.
+func SendWebhookEvent(guildID, event string, data any) {
+	var hooks []webhook.Hook
+	for _, hook := range GetWebhooks(guildID) {
+		hooks = append(hooks, webhook.Hook{URL: hook.URL, Secret: hook.Secret})
+	}
+	webhook.Send(hooks, guildID, event, data)
+}
+
+// SendCompetitionUpdated sends the latest standings of the running event,
+// it's meant to be called right after RecordStandingsSnapshot.
+func SendCompetitionUpdated(guildID string) {
+	snapshots, err := GetStandingsSnapshots(guildID)
+	if err != nil {
+		utils.LogError("error when fetching the standings for webhooks", err)
+		return
+	}
+	if len(snapshots) == 0 {
+		return
+	}
+	SendWebhookEvent(guildID, WebhookCompetitionUpdated, snapshots[len(snapshots)-1])
+}
+
+// sendPointsAwarded sends the points the event's standings earned.
+func sendPointsAwarded(guildID string, result CompetitionResult) {
+	participants, err := getParticipants(guildID)
+	if err != nil {
+		utils.LogError("error when fetching participants for webhooks", err)
+		return
+	}
+
+	event := PointsAwardedEvent{Activity: result.Activity, Awards: []PointsAward{}}
+	for _, standing := range result.Standings {
+		if standing.Points == 0 {
+			continue
+		}
+		event.Awards = append(event.Awards, PointsAward{
+			DiscordId:   standing.DiscordId,
+			Rank:        standing.Rank,
+			Points:      standing.Points,
+			TotalPoints: participants[standing.DiscordId].Points,
+		})
+	}
+
+	SendWebhookEvent(guildID, WebhookPointsAwarded, event)
+}
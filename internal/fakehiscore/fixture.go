@@ -0,0 +1,71 @@
+package fakehiscore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Fixture scripts what the fake hiscores return over simulated time.
+type Fixture struct {
+	// Players maps a username to its timeline, ordered by At.
+	Players map[string][]Step `json:"players"`
+	// Outages make every request fail with a status for a stretch of time.
+	Outages []Outage `json:"outages"`
+}
+
+// Step changes a player's hiscores from a point in simulated time onwards.
+// Skills and activities only override the names they mention, everything
+// else keeps the value of the previous steps. A non-zero Status makes every
+// request for the player fail with that status until the next step.
+type Step struct {
+	At         Duration       `json:"at"`
+	Skills     map[string]int `json:"skills,omitempty"`
+	Activities map[string]int `json:"activities,omitempty"`
+	Status     int            `json:"status,omitempty"`
+}
+
+// Outage fails every request with Status between From and Until.
+type Outage struct {
+	From   Duration `json:"from"`
+	Until  Duration `json:"until"`
+	Status int      `json:"status"`
+}
+
+// Duration is a time.Duration written as "90m" or "2h" in fixture files.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"2h\": %w", err)
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadFixture reads a fixture from a JSON file.
+func LoadFixture(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("failed to unmarshal fixture: %w", err)
+	}
+
+	return fixture, nil
+}
@@ -0,0 +1,262 @@
+// Package fakehiscore is a stand-in for the OSRS hiscores, driven by scripted
+// fixtures. Point the bot at it with service.SetBaseURL (or HISCORE_BASE_URL)
+// to exercise tracking, updates and errors without secure.runescape.com.
+package fakehiscore
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"misclicked-events/internal/service"
+)
+
+// Server serves index_lite.json and index_lite.ws for the scripted players.
+// It implements http.Handler, so it can run behind httptest.NewServer or
+// http.ListenAndServe.
+type Server struct {
+	mu       sync.Mutex
+	fixture  Fixture
+	now      time.Duration
+	failures map[string][]int
+	requests map[string]int
+}
+
+// New creates a server at simulated time zero.
+func New(fixture Fixture) *Server {
+	players := make(map[string][]Step, len(fixture.Players))
+	for name, steps := range fixture.Players {
+		sorted := append([]Step(nil), steps...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].At < sorted[j].At
+		})
+		players[normalize(name)] = sorted
+	}
+	fixture.Players = players
+
+	return &Server{
+		fixture:  fixture,
+		failures: make(map[string][]int),
+		requests: make(map[string]int),
+	}
+}
+
+// Advance moves simulated time forward.
+func (srv *Server) Advance(d time.Duration) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.now += d
+}
+
+// Now returns the current simulated time.
+func (srv *Server) Now() time.Duration {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.now
+}
+
+// FailNext makes the next count requests for username fail with status.
+// An empty username fails the next count requests for any player.
+func (srv *Server) FailNext(username string, status, count int) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	key := normalize(username)
+	for range count {
+		srv.failures[key] = append(srv.failures[key], status)
+	}
+}
+
+// Requests returns how many requests were made for username.
+func (srv *Server) Requests(username string) int {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.requests[normalize(username)]
+}
+
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/_fake/") {
+		srv.serveControl(w, r)
+		return
+	}
+
+	var format string
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/index_lite.json"):
+		format = "json"
+	case strings.HasSuffix(r.URL.Path, "/index_lite.ws"):
+		format = "csv"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	username := r.URL.Query().Get("player")
+	skills, activities, status := srv.lookup(username)
+	if status != http.StatusOK {
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Name       string             `json:"name"`
+			Skills     []service.Skill    `json:"skills"`
+			Activities []service.Activity `json:"activities"`
+		}{username, skills, activities})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	for _, skill := range skills {
+		fmt.Fprintf(w, "%d,%d,%d\n", skill.Rank, skill.Level, skill.XP)
+	}
+	for _, activity := range activities {
+		fmt.Fprintf(w, "%d,%d\n", activity.Rank, activity.Score)
+	}
+}
+
+// serveControl lets a running server be driven over HTTP:
+//
+//	POST /_fake/advance?by=1h
+//	POST /_fake/fail?player=name&status=429&count=2
+func (srv *Server) serveControl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "use POST", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	switch r.URL.Path {
+	case "/_fake/advance":
+		d, err := time.ParseDuration(query.Get("by"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		srv.Advance(d)
+	case "/_fake/fail":
+		status, err := strconv.Atoi(query.Get("status"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		count := 1
+		if c := query.Get("count"); c != "" {
+			if count, err = strconv.Atoi(c); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		srv.FailNext(query.Get("player"), status, count)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	fmt.Fprintf(w, "now=%s\n", srv.Now())
+}
+
+// lookup resolves the hiscores of username at the current simulated time,
+// or the status code the request should fail with.
+func (srv *Server) lookup(username string) ([]service.Skill, []service.Activity, int) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	key := normalize(username)
+	srv.requests[key]++
+
+	if status, ok := srv.popFailure(key); ok {
+		return nil, nil, status
+	}
+	if status, ok := srv.popFailure(""); ok {
+		return nil, nil, status
+	}
+
+	for _, outage := range srv.fixture.Outages {
+		if srv.now >= time.Duration(outage.From) && srv.now < time.Duration(outage.Until) {
+			return nil, nil, outage.Status
+		}
+	}
+
+	steps, ok := srv.fixture.Players[key]
+	if !ok || len(steps) == 0 || time.Duration(steps[0].At) > srv.now {
+		return nil, nil, http.StatusNotFound
+	}
+
+	xp := map[string]int{}
+	scores := map[string]int{}
+	status := http.StatusOK
+	for _, step := range steps {
+		if time.Duration(step.At) > srv.now {
+			break
+		}
+		for name, value := range step.Skills {
+			xp[name] = value
+		}
+		for name, value := range step.Activities {
+			scores[name] = value
+		}
+		status = http.StatusOK
+		if step.Status != 0 {
+			status = step.Status
+		}
+	}
+	if status != http.StatusOK {
+		return nil, nil, status
+	}
+
+	skillNames := service.SkillNames()
+	skills := make([]service.Skill, len(skillNames))
+	for i, name := range skillNames {
+		skills[i] = service.Skill{ID: i, Name: name, Rank: -1, Level: 1, XP: -1}
+		if value, ok := xp[name]; ok {
+			skills[i].Rank = 1
+			skills[i].Level = levelForXP(value)
+			skills[i].XP = value
+		}
+	}
+
+	activityNames := service.ActivityNames()
+	activities := make([]service.Activity, len(activityNames))
+	for i, name := range activityNames {
+		activities[i] = service.Activity{ID: i, Name: name, Rank: -1, Score: -1}
+		if value, ok := scores[name]; ok {
+			activities[i].Rank = 1
+			activities[i].Score = value
+		}
+	}
+
+	return skills, activities, http.StatusOK
+}
+
+func (srv *Server) popFailure(key string) (int, bool) {
+	queue := srv.failures[key]
+	if len(queue) == 0 {
+		return 0, false
+	}
+	srv.failures[key] = queue[1:]
+	return queue[0], true
+}
+
+// levelForXP uses the in-game experience table, capped at 99.
+func levelForXP(xp int) int {
+	points := 0.0
+	for level := 1; level < 99; level++ {
+		points += math.Floor(float64(level) + 300*math.Pow(2, float64(level)/7))
+		if int(math.Floor(points/4)) > xp {
+			return level
+		}
+	}
+	return 99
+}
+
+func normalize(username string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(username)), "_", " ")
+}
@@ -0,0 +1,181 @@
+package fakehiscore
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"misclicked-events/internal/service"
+)
+
+func loadExample(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+
+	fixture, err := LoadFixture("testdata/example.json")
+	if err != nil {
+		t.Fatalf("LoadFixture: %v", err)
+	}
+
+	srv := New(fixture)
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return srv, ts
+}
+
+// fetch requests the JSON hiscores of player, returning the status and, for
+// a 200, the score of every ranked activity.
+func fetch(t *testing.T, ts *httptest.Server, player string) (int, map[string]int) {
+	t.Helper()
+
+	resp, err := http.Get(ts.URL + "/m=hiscore_oldschool/index_lite.json?player=" + url.QueryEscape(player))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return resp.StatusCode, nil
+	}
+
+	var body struct {
+		Activities []service.Activity `json:"activities"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	scores := make(map[string]int)
+	for _, activity := range body.Activities {
+		if activity.Score >= 0 {
+			scores[activity.Name] = activity.Score
+		}
+	}
+	return resp.StatusCode, scores
+}
+
+func TestServerPlaysTheTimeline(t *testing.T) {
+	srv, ts := loadExample(t)
+
+	tests := []struct {
+		at     time.Duration
+		status int
+		zulrah int
+	}{
+		{0, http.StatusOK, 100},
+		{90 * time.Minute, http.StatusOK, 104},
+		{2 * time.Hour, http.StatusOK, 109},
+		{3 * time.Hour, http.StatusTooManyRequests, 0},
+		{4 * time.Hour, http.StatusOK, 115},
+		{7 * time.Hour, http.StatusInternalServerError, 0},
+		{8 * time.Hour, http.StatusOK, 115},
+	}
+
+	for _, tt := range tests {
+		srv.Advance(tt.at - srv.Now())
+
+		status, scores := fetch(t, ts, "zezima")
+		if status != tt.status {
+			t.Errorf("at %s: status %d, want %d", tt.at, status, tt.status)
+			continue
+		}
+		if status != http.StatusOK {
+			continue
+		}
+		if scores["Zulrah"] != tt.zulrah {
+			t.Errorf("at %s: Zulrah %d, want %d", tt.at, scores["Zulrah"], tt.zulrah)
+		}
+		// Steps only override what they mention
+		if scores["Vorkath"] != 40 {
+			t.Errorf("at %s: Vorkath %d, want 40 from the first step", tt.at, scores["Vorkath"])
+		}
+	}
+
+	if status, _ := fetch(t, ts, "Nobody"); status != http.StatusNotFound {
+		t.Errorf("unknown player: status %d, want 404", status)
+	}
+	if got := srv.Requests("Zezima"); got != len(tests) {
+		t.Errorf("Requests = %d, want %d", got, len(tests))
+	}
+}
+
+func TestServerFailNext(t *testing.T) {
+	srv, ts := loadExample(t)
+
+	srv.FailNext("Lynx_Titan", http.StatusServiceUnavailable, 2)
+	srv.FailNext("", http.StatusTooManyRequests, 1)
+
+	want := []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}
+	for i, status := range want {
+		if got, _ := fetch(t, ts, "Lynx Titan"); got != status {
+			t.Errorf("request %d for Lynx Titan: status %d, want %d", i, got, status)
+		}
+		if i == 0 {
+			// The failure for any player goes to whoever asks first
+			if got, _ := fetch(t, ts, "Zezima"); got != http.StatusTooManyRequests {
+				t.Errorf("Zezima: status %d, want 429", got)
+			}
+		}
+	}
+}
+
+func TestServerControlEndpoints(t *testing.T) {
+	srv, ts := loadExample(t)
+
+	resp, err := http.Post(ts.URL+"/_fake/advance?by=2h", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if srv.Now() != 2*time.Hour {
+		t.Errorf("Now = %s after advancing 2h", srv.Now())
+	}
+
+	resp, err = http.Post(ts.URL+"/_fake/fail?player=Zezima&status=500", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if status, _ := fetch(t, ts, "Zezima"); status != http.StatusInternalServerError {
+		t.Errorf("status %d after /_fake/fail, want 500", status)
+	}
+
+	resp, err = http.Get(ts.URL + "/_fake/advance?by=1h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET on a control endpoint: status %d, want 405", resp.StatusCode)
+	}
+}
+
+func TestServerCSVHasALinePerEntry(t *testing.T) {
+	_, ts := loadExample(t)
+
+	resp, err := http.Get(ts.URL + "/m=hiscore_oldschool/index_lite.ws?player=Zezima")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines++
+	}
+	if want := len(service.SkillNames()) + len(service.ActivityNames()); lines != want {
+		t.Errorf("CSV has %d lines, want %d", lines, want)
+	}
+}
+
+func TestLevelForXP(t *testing.T) {
+	tests := map[int]int{0: 1, 82: 1, 83: 2, 1_154: 10, 13_034_430: 98, 13_034_431: 99, 200_000_000: 99}
+	for xp, want := range tests {
+		if got := levelForXP(xp); got != want {
+			t.Errorf("levelForXP(%d) = %d, want %d", xp, got, want)
+		}
+	}
+}
@@ -1,29 +1,61 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"misclicked-events/internal/commands"
+	"misclicked-events/internal/i18n"
 	"misclicked-events/internal/utils"
+	"runtime/debug"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
 
 func InteractionCreateHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	switch i.ApplicationCommandData().Name {
-	case "setup-channels":
-		commands.HandleConfigCommand(s, i)
-	case "track":
-		commands.HandleTrackNewAccountCommand(s, i)
-	case "untrack":
-		commands.HandleUnTrackAccountCommand(s, i)
-	case "tracking":
-		commands.HandleTrackedAccountsCommand(s, i)
-	case "start":
-		commands.HandleStartActivityCommand(s, i)
-	case "end":
-		commands.HandleEndActivityCommand(s, i)
-	case "rename":
-		commands.HandleRenameAccountCommand(s, i)
+	defer recoverInteraction(s, i)
+
+	switch i.Type {
+	case discordgo.InteractionApplicationCommand:
+		commands.HandleCommand(s, i)
+	case discordgo.InteractionApplicationCommandAutocomplete:
+		commands.HandleAutocomplete(s, i)
+	case discordgo.InteractionMessageComponent:
+		handleMessageComponent(s, i)
+	}
+}
+
+// recoverInteraction keeps a panic in any handler from taking the bot down.
+// Slash commands also recover in their middleware, where they can still edit
+// the deferred response; buttons and modals get an error instead, and
+// autocomplete has no way to show one.
+func recoverInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	utils.LogError(fmt.Sprintf("Panic handling %s interaction", i.Type), fmt.Errorf("%v\n%s", r, debug.Stack()))
+
+	if i.Type == discordgo.InteractionMessageComponent || i.Type == discordgo.InteractionModalSubmit {
+		utils.RespondWithError(s, i, errors.New(i18n.ForGuild(i.GuildID).Sprintf("something went wrong, try again later")))
+	}
+}
+
+func handleMessageComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Custom IDs are "<prefix>:<arguments>"
+	prefix, _, _ := strings.Cut(i.MessageComponentData().CustomID, ":")
+
+	switch prefix {
+	case commands.KCReviewButtonPrefix:
+		commands.HandleKCReviewButton(s, i)
+	case commands.LeaderboardButtonPrefix:
+		commands.HandleLeaderboardButton(s, i)
+	case commands.AccountClaimButtonPrefix:
+		commands.HandleAccountClaimButton(s, i)
+	case commands.VerifyButtonPrefix:
+		commands.HandleVerifyButton(s, i)
 	default:
-		utils.LogError("Unknown command", nil)
+		utils.LogError("Unknown component", nil)
 	}
 }
@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// Locales the bot speaks, English is the fallback and needs no translations
+var supported = []language.Tag{language.English, language.Dutch}
+
+var matcher = language.NewMatcher(supported)
+
+var builder = catalog.NewBuilder(catalog.Fallback(language.English))
+
+// translations mirrors the catalog so the command definitions can look
+// translations up without formatting them.
+var translations = map[language.Tag]map[string]string{}
+
+// guildLocale looks up the locale a guild picked, see SetGuildLocaleLookup.
+var guildLocale = func(guildID string) string { return "" }
+
+func set(tag language.Tag, messages map[string]string) {
+	translations[tag] = messages
+	for key, msg := range messages {
+		err := builder.SetString(tag, key, msg)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// SetGuildLocaleLookup sets where the locale of a guild comes from.
+func SetGuildLocaleLookup(lookup func(guildID string) string) {
+	guildLocale = lookup
+}
+
+// Printer returns a printer for the closest supported locale. The English
+// format strings are the message keys, so they're printed as is when there
+// is no translation.
+func Printer(locale string) *message.Printer {
+	_, index := language.MatchStrings(matcher, locale)
+	return message.NewPrinter(supported[index], message.Catalog(builder))
+}
+
+// ForGuild returns a printer for the locale the guild picked.
+func ForGuild(guildID string) *message.Printer {
+	return Printer(guildLocale(guildID))
+}
+
+// Normalize returns the supported locale closest to the given one.
+func Normalize(locale string) string {
+	_, index := language.MatchStrings(matcher, locale)
+	return supported[index].String()
+}
+
+// Translations returns the translations of a message per locale, leaving out
+// the locales that don't translate it.
+func Translations(key string) map[string]string {
+	result := map[string]string{}
+	for tag, messages := range translations {
+		if msg, ok := messages[key]; ok && msg != key {
+			result[tag.String()] = msg
+		}
+	}
+	return result
+}
@@ -0,0 +1,404 @@
+package i18n
+
+import "golang.org/x/text/language"
+
+func init() {
+	set(language.Dutch, map[string]string{
+		// Command, option and choice names. Discord wants names lowercase
+		// without spaces, choices can be anything.
+		"setup-channels":          "kanalen-instellen",
+		"track":                   "volgen",
+		"untrack":                 "ontvolgen",
+		"tracking":                "gevolgd",
+		"end":                     "beëindigen",
+		"rename":                  "hernoemen",
+		"stats":                   "statistieken",
+		"leaderboard":             "ranglijst",
+		"language":                "taal",
+		"chart":                   "grafiek",
+		"overall_ranking_channel": "kanaal_totaalklassement",
+		"botm_ranking_channel":    "kanaal_botm_ranglijst",
+		"category_channel":        "categorie",
+		"admin_channel":           "beheerkanaal",
+		"announcement_channel":    "aankondigingskanaal",
+		"announcement_role":       "aankondigingsrol",
+		"username":                "gebruikersnaam",
+		"choice":                  "keuze",
+		"password":                "wachtwoord",
+		"old_username":            "oude_gebruikersnaam",
+		"new_username":            "nieuwe_gebruikersnaam",
+		"user":                    "lid",
+		"board":                   "klassement",
+		"me":                      "ik",
+		"page":                    "pagina",
+		"Current event":           "Huidig evenement",
+		"Overall ranking":         "Totaalklassement",
+
+		// Command and option descriptions
+		"setup channels to show competition results":                                              "kanalen instellen om de resultaten van competities te tonen",
+		"Select a channel to show overall competition ranking":                                    "Kies een kanaal voor het totaalklassement",
+		"Select a channel to show BOTM ranking":                                                   "Kies een kanaal voor de BOTM-ranglijst",
+		"Select a channels category":                                                              "Kies een kanaalcategorie",
+		"Select a channel for reports that need an admin":                                         "Kies een kanaal voor meldingen die een beheerder nodig hebben",
+		"Select a channel to announce the podium when an event ends":                              "Kies een kanaal om het podium aan te kondigen als een evenement eindigt",
+		"Select a role to mention in the podium announcement":                                     "Kies een rol om te vermelden in de podiumaankondiging",
+		"Link an OSRS account to your profile to track its progress — only add accounts you own.": "Koppel een OSRS-account aan je profiel om de voortgang te volgen — voeg alleen je eigen accounts toe.",
+		"The username to start tracking":                                                          "De gebruikersnaam om te volgen",
+		"Untracks an OSRS account from your profile":                                              "Stopt met het volgen van een OSRS-account op je profiel",
+		"The OSRS account username to stop tracking":                                              "De gebruikersnaam van het OSRS-account om niet meer te volgen",
+		"accounts you're currently tracking":                                                      "accounts die je op dit moment volgt",
+		"Select an activity to start":                                                             "Kies een activiteit om te starten",
+		"Choose an activity":                                                                      "Kies een activiteit",
+		"Set an activity password, needed to end it by admins who aren't organizers":              "Stel een wachtwoord in voor de activiteit, nodig voor beheerders die geen organisator zijn om haar te beëindigen",
+		"End the current activity":                                                                "Beëindig de huidige activiteit",
+		"provide the activity password, organizers can leave it empty":                            "geef het wachtwoord van de activiteit, organisatoren kunnen het leeg laten",
+		"that's not the password of this event":                                                   "dat is niet het wachtwoord van dit evenement",
+		"Rename one of your tracked OSRS accounts":                                                "Hernoem een van je gevolgde OSRS-accounts",
+		"The current username of the account":                                                     "De huidige gebruikersnaam van het account",
+		"The new username to change to":                                                           "De nieuwe gebruikersnaam",
+		"Show the competition record of a member":                                                 "Toon de competitieresultaten van een lid",
+		"The member to show, yourself if left empty":                                              "Het lid om te tonen, jijzelf als je het leeg laat",
+		"Check the standings without leaving the channel":                                         "Bekijk de stand zonder het kanaal te verlaten",
+		"Which standings to show, the current event if left empty":                                "Welke stand je wilt zien, het huidige evenement als je het leeg laat",
+		"Only show participants with an OSRS account matching this name":                          "Toon alleen deelnemers met een OSRS-account dat overeenkomt met deze naam",
+		"Jump to your own position":                                                               "Spring naar je eigen positie",
+		"The page to show":                                                                        "De pagina om te tonen",
+		"Pick the language the bot uses in this server":                                           "Kies de taal die de bot in deze server gebruikt",
+		"Draw the KC over the event as a chart":                                                   "Teken de KC over het evenement als grafiek",
+		"How many of the leading participants to show":                                            "Hoeveel van de koplopers je wilt zien",
+		"Show the accounts of this member instead":                                                "Toon in plaats daarvan de accounts van dit lid",
+		"The language to use":                                                                     "De taal om te gebruiken",
+
+		// Responses and errors
+		"⚠️ **Error**\n%s":                                                                 "⚠️ **Fout**\n%s",
+		"An Error Occurred":                                                                "Er is een fout opgetreden",
+		"unknown error occurred":                                                           "er is een onbekende fout opgetreden",
+		"you don't have the required permissions":                                          "je hebt niet de vereiste rechten",
+		"this command can only be used in a server":                                        "dit commando kan alleen in een server gebruikt worden",
+		"something went wrong while running this command":                                  "er ging iets mis bij het uitvoeren van dit commando",
+		"something went wrong while trying to update the config":                           "er ging iets mis bij het bijwerken van de configuratie",
+		"Config saved!":                                                                    "Configuratie opgeslagen!",
+		"set up the channels with `/setup-channels` before picking a language":             "stel eerst de kanalen in met `/setup-channels` voordat je een taal kiest",
+		"The bot now speaks English in this server.":                                       "De bot spreekt nu Nederlands in deze server.",
+		"could not track the account '%s': %v":                                             "kon het account '%s' niet volgen: %v",
+		"Successfully started tracking the OSRS account: **%s**":                           "Het OSRS-account **%s** wordt nu gevolgd",
+		"could not untrack the account '%s': %v":                                           "kon het account '%s' niet ontvolgen: %v",
+		"Successfully stopped tracking the OSRS account: **%s**.":                          "Het OSRS-account **%s** wordt niet meer gevolgd.",
+		"could not find an OSRS account with the username: %s":                             "kon geen OSRS-account vinden met de gebruikersnaam: %s",
+		"could not rename the account: %v":                                                 "kon het account niet hernoemen: %v",
+		"Successfully renamed account from **%s** to **%s**":                               "Account hernoemd van **%s** naar **%s**",
+		"You have no tracked accounts at the moment. Use `/track` to start tracking one!":  "Je volgt op dit moment geen accounts. Gebruik `/track` om er een te volgen!",
+		"**Event:** %s\n\n":                                                                "**Evenement:** %s\n\n",
+		"⚠️ **%s**\n   └ *Not found on the hiscores, use `/rename` if you renamed it*\n\n": "⚠️ **%s**\n   └ *Niet gevonden op de hiscores, gebruik `/rename` als je het hebt hernoemd*\n\n",
+		"🔹 **%s**\n   └ *Not participating in the current event*\n":                        "🔹 **%s**\n   └ *Doet niet mee aan het huidige evenement*\n",
+		"Currently Tracked Accounts":                                                       "Gevolgde accounts",
+		"An activity has already been selected: \"**%s**\", You need to end this activity before starting a new one.":               "Er is al een activiteit gekozen: \"**%s**\". Beëindig deze activiteit voordat je een nieuwe start.",
+		"Something went wrong trying to start this activity.":                                                                       "Er ging iets mis bij het starten van deze activiteit.",
+		"Activity selected: **%s**, now tracking kc for: **%s**":                                                                    "Activiteit gekozen: **%s**, nu wordt de kc gevolgd voor: **%s**",
+		"something went wrong while trying to end the event":                                                                        "er ging iets mis bij het beëindigen van het evenement",
+		"the event can't end while KC changes are waiting for review, approve or reject them with `/kc-reviews resolve` first:\n%s": "het evenement kan niet eindigen zolang er KC-wijzigingen op een beoordeling wachten, keur ze eerst goed of af met `/kc-reviews resolve`:\n%s",
+		"the hiscores no longer list *%s*, so the final KC can't be trusted. The event can end once the activity catalog is fixed":  "de hiscores tonen *%s* niet meer, dus de laatste KC is niet betrouwbaar. Het evenement kan eindigen zodra de activiteitencatalogus is aangepast",
+		"✅ The event has ended on the last known KC because the hiscores are unavailable, and the rankings have been updated!":      "✅ Het evenement is afgelopen op de laatst bekende KC omdat de hiscores niet bereikbaar zijn, en het klassement is bijgewerkt!",
+		"%s is already tracked by <@%s>":                              "%s wordt al gevolgd door <@%s>",
+		"there is no OSRS account with this name on the hiscores":     "er staat geen OSRS-account met deze naam op de hiscores",
+		"the OSRS hiscores are unavailable, try again later":          "de OSRS-hiscores zijn niet bereikbaar, probeer het later opnieuw",
+		"the account is already being tracked":                        "het account wordt al gevolgd",
+		"no accounts are being tracked for this member":               "er worden geen accounts gevolgd voor dit lid",
+		"no tracked account has this name":                            "er wordt geen account met deze naam gevolgd",
+		"the account already belongs to this member":                  "het account is al van dit lid",
+		"nobody is tracking this account anymore, try `/track` again": "niemand volgt dit account meer, probeer `/track` opnieuw",
+		"this claim has already been handled":                         "deze claim is al afgehandeld",
+		"this review has already been handled":                        "deze beoordeling is al afgehandeld",
+		"something went wrong, try again later":                       "er ging iets mis, probeer het later opnieuw",
+		"something went wrong while updating the ranking message":     "er ging iets mis bij het bijwerken van het klassement",
+		"✅ The event has ended, and the rankings have been updated!":  "✅ Het evenement is afgelopen en het klassement is bijgewerkt!",
+
+		// Leaderboards
+		"Competition Ranking":                       "Competitieklassement",
+		"🚨 Participants don't have any points yet!": "🚨 Deelnemers hebben nog geen punten!",
+		"%s  **<@%s>**  -  _%d pts_\n":              "%s  **<@%s>**  -  _%d ptn_\n",
+		"%s %s - _%d pts_\n":                        "%s %s - _%d ptn_\n",
+		"Killcount Leaderboard":                     "Killcount-ranglijst",
+		"🏆 Killcount Leaderboard":                   "🏆 Killcount-ranglijst",
+		"🔄 Last updated: %s":                        "🔄 Laatst bijgewerkt: %s",
+		"🔄 Last updated: unknown":                   "🔄 Laatst bijgewerkt: onbekend",
+		"> ⚠️ **OSRS hiscores unavailable since %s**\n> KC is shown as it was before the outage.\n\n": "> ⚠️ **OSRS-hiscores onbereikbaar sinds %s**\n> De KC wordt getoond zoals die voor de storing was.\n\n",
+		"### Tracked Bosses:\n":                                              "### Gevolgde bazen:\n",
+		"\n🚨 No participants have enough KC yet!\n":                          "\n🚨 Nog geen deelnemers met genoeg KC!\n",
+		"### Leaderboard:\n":                                                 "### Ranglijst:\n",
+		"_Threshold: %dkc_\n":                                                "_Drempel: %dkc_\n",
+		"_(+KC since the last update, +KC in the last 24 hours)_\n":          "_(+KC sinds de vorige update, +KC in de laatste 24 uur)_\n",
+		"_⚠️ stale: not found on the hiscores, showing the last known KC_\n": "_⚠️ verouderd: niet gevonden op de hiscores, de laatst bekende KC wordt getoond_\n",
+		" ⚠️ _stale_":                                                        " ⚠️ _verouderd_",
+		"%s %s%s - **Total KC:** `%d`%s\n%s\n":                               "%s %s%s - **Totale KC:** `%d`%s\n%s\n",
+		" _(%+d, %+d in 24h)_":                                               " _(%+d, %+d in 24u)_",
+		"🚨 No Ongoing Event":                                                 "🚨 Geen lopend evenement",
+		"There is currently no ongoing event. Use the appropriate command to start a new event!": "Er loopt op dit moment geen evenement. Gebruik het juiste commando om een nieuw evenement te starten!",
+		"🆕 Last updated: %s": "🆕 Laatst bijgewerkt: %s",
+		"\n⏸️ **KC updates are paused:** the hiscores no longer list *%s*.\n": "\n⏸️ **KC-updates zijn gepauzeerd:** de hiscores tonen *%s* niet meer.\n",
+		"👑 Overall Ranking":                                          "👑 Totaalklassement",
+		"🏆 %s Leaderboard":                                           "🏆 %s-ranglijst",
+		"### Below the threshold (%dkc)\n":                           "### Onder de drempel (%dkc)\n",
+		"_Accounts matching_ `%s`\n\n":                               "_Accounts die overeenkomen met_ `%s`\n\n",
+		"🚨 Nobody to show here yet!":                                 "🚨 Hier valt nog niemand te tonen!",
+		"Page %d/%d":                                                 "Pagina %d/%d",
+		"something went wrong while fetching the ranking":            "er ging iets mis bij het ophalen van het klassement",
+		"something went wrong while fetching the leaderboard":        "er ging iets mis bij het ophalen van de ranglijst",
+		"there is no event running, try the overall ranking instead": "er loopt geen evenement, probeer het totaalklassement",
+		"Refresh now":                                                "Nu verversen",
+		"Show my position":                                           "Toon mijn positie",
+		"Browse pages":                                               "Blader door pagina's",
+		"Previous page":                                              "Vorige pagina",
+		"Next page":                                                  "Volgende pagina",
+		"unknown leaderboard button":                                 "onbekende ranglijstknop",
+		"there is no event running":                                  "er loopt geen evenement",
+		"the OSRS hiscores are unavailable right now, try again later":                    "de OSRS-hiscores zijn op dit moment onbereikbaar, probeer het later opnieuw",
+		"⏳ The leaderboard was updated recently, you can refresh it again in %d minutes.": "⏳ De ranglijst is net bijgewerkt, je kunt over %d minuten opnieuw verversen.",
+		"✅ The leaderboard has been refreshed.":                                           "✅ De ranglijst is ververst.",
+		"you're not on this leaderboard":                                                  "je staat niet op deze ranglijst",
+
+		// Leaderboard images
+		"No participants have enough KC yet!":     "Nog geen deelnemers met genoeg KC!",
+		"Participants don't have any points yet!": "Deelnemers hebben nog geen punten!",
+		"%d KC":           "%d KC",
+		"+%d pts":         "+%d ptn",
+		"%d pts":          "%d ptn",
+		" (stale)":        " (verouderd)",
+		"... and %d more": "... en nog %d",
+
+		// Admin reports
+		"⚠️ Account not found on the hiscores": "⚠️ Account niet gevonden op de hiscores",
+		"We haven't been able to find **%s** on the OSRS hiscores for the last %d updates.\n\nIf you renamed the account, use `/rename` so we can keep tracking it. Until then the leaderboard keeps its last known KC.": "We konden **%s** de laatste %d updates niet vinden op de OSRS-hiscores.\n\nAls je het account hebt hernoemd, gebruik dan `/rename` zodat we het kunnen blijven volgen. Tot die tijd houdt de ranglijst de laatst bekende KC aan.",
+		"⚠️ Stale accounts": "⚠️ Verouderde accounts",
+		"These accounts were missing from the hiscores for %d updates in a row:\n\n%s": "Deze accounts ontbraken %d updates op rij op de hiscores:\n\n%s",
+		"🔎 Suspicious KC change": "🔎 Verdachte KC-wijziging",
+		"**%s** (<@%s>) went from `%d` to `%d` %s KC (%+d).\n\nThe change isn't on the leaderboard until it's approved. Rejecting it keeps the current KC and only counts gains after this one.": "**%s** (<@%s>) ging van `%d` naar `%d` %s KC (%+d).\n\nDe wijziging komt pas op de ranglijst als ze is goedgekeurd. Afwijzen houdt de huidige KC aan en telt alleen wat er hierna bij komt.",
+		"Approve":    "Goedkeuren",
+		"kc-reviews": "kc-beoordelingen",
+		"resolve":    "afhandelen",
+		"review":     "beoordeling",
+		"decision":   "besluit",
+		"Settle KC changes that were held back for review":                                     "Handel KC-wijzigingen af die voor een beoordeling zijn tegengehouden",
+		"Show the KC changes waiting for review":                                               "Toon de KC-wijzigingen die op een beoordeling wachten",
+		"Approve or reject a KC change":                                                        "Keur een KC-wijziging goed of af",
+		"The KC change to settle":                                                              "De KC-wijziging om af te handelen",
+		"Whether the change counts":                                                            "Of de wijziging meetelt",
+		"something went wrong while fetching the KC reviews":                                   "er ging iets mis bij het ophalen van de KC-beoordelingen",
+		"No KC changes are waiting for review.":                                                "Er wachten geen KC-wijzigingen op een beoordeling.",
+		"• **%s** (<@%s>): `%d` → `%d` %s KC (%+d)":                                            "• **%s** (<@%s>): `%d` → `%d` %s KC (%+d)",
+		"These KC changes are waiting for review, settle them with `/kc-reviews resolve`:\n%s": "Deze KC-wijzigingen wachten op een beoordeling, handel ze af met `/kc-reviews resolve`:\n%s",
+		"✅ Approved the change of **%s** to `%d` %s KC.":                                       "✅ De wijziging van **%s** naar `%d` %s KC is goedgekeurd.",
+		"❌ Rejected the change of **%s** to `%d` %s KC.":                                       "❌ De wijziging van **%s** naar `%d` %s KC is afgewezen.",
+		"Reject":                       "Afwijzen",
+		"unknown review button":        "onbekende beoordelingsknop",
+		"❌ Rejected":                   "❌ Afgewezen",
+		"✅ Approved":                   "✅ Goedgekeurd",
+		"%s by %s":                     "%s door %s",
+		"✅ Hiscores look normal again": "✅ De hiscores zien er weer normaal uit",
+		"Every boss name in the activity catalog is back on the hiscores, KC updates have resumed.":        "Elke baasnaam uit de activiteitencatalogus staat weer op de hiscores, de KC-updates zijn hervat.",
+		"The hiscores no longer list these boss names:\n• %s\n\n":                                          "De hiscores tonen deze baasnamen niet meer:\n• %s\n\n",
+		"They're part of the current event, so KC updates are paused until the activity catalog is fixed.": "Ze horen bij het huidige evenement, dus de KC-updates zijn gepauzeerd tot de activiteitencatalogus is aangepast.",
+		"The current event isn't affected, but activities using them need fixing before they're started.":  "Het huidige evenement heeft er geen last van, maar activiteiten die ze gebruiken moeten worden aangepast voordat ze starten.",
+		"🚨 Hiscore format changed": "🚨 Formaat van de hiscores gewijzigd",
+
+		// Stats
+		"something went wrong while fetching the stats": "er ging iets mis bij het ophalen van de statistieken",
+		"_unranked_":     "_zonder rang_",
+		"📊 Stats for %s": "📊 Statistieken van %s",
+		"🏆 **%d** wins  •  🥇🥈🥉 **%d** podiums\n\n**Points:** `%d`  •  **Overall rank:** %s  •  **Events entered:** `%d`": "🏆 **%d** overwinningen  •  🥇🥈🥉 **%d** podiumplaatsen\n\n**Punten:** `%d`  •  **Totaalrang:** %s  •  **Evenementen:** `%d`",
+		"\n\nNo finished events yet, join the next one with `/track`!":                                                   "\n\nNog geen afgelopen evenementen, doe mee aan het volgende met `/track`!",
+		"_... and %d more_\n":        "_... en nog %d_\n",
+		"below threshold":            "onder de drempel",
+		"**%s:** %s, best `%d` KC\n": "**%s:** %s, beste `%d` KC\n",
+		"_No KC yet_":                "_Nog geen KC_",
+		"Placements":                 "Klasseringen",
+		"Best per boss":              "Beste per baas",
+		"Account contributions":      "Bijdrage per account",
+		"#%d, +%d pts":               "#%d, +%d ptn",
+
+		// Podium announcement
+		"🎉 %s event has ended!":                                                            "🎉 Het %s-evenement is afgelopen!",
+		"%s **<@%s>** - `%d` KC, _+%d pts_\n":                                              "%s **<@%s>** - `%d` KC, _+%d ptn_\n",
+		"Nobody reached the threshold of %dkc this time.":                                  "Niemand haalde deze keer de drempel van %dkc.",
+		"Congratulations to <@%s> for winning the event with `%d` KC! 👑\n\n### Podium\n%s": "Gefeliciteerd <@%s>, winnaar van het evenement met `%d` KC! 👑\n\n### Podium\n%s",
+		"Total clan KC":         "Totale clan-KC",
+		"Reached the threshold": "Drempel gehaald",
+		"%d of %d (%dkc)":       "%d van %d (%dkc)",
+		"MVP account":           "MVP-account",
+
+		// Charts
+		"something went wrong while fetching the KC history": "er ging iets mis bij het ophalen van de KC-geschiedenis",
+		"there is no KC history to draw yet":                 "er is nog geen KC-geschiedenis om te tekenen",
+		"something went wrong while drawing the chart":       "er ging iets mis bij het tekenen van de grafiek",
+		"KC over the event":                                  "KC tijdens het evenement",
+		"Nobody has KC yet!":                                 "Nog niemand heeft KC!",
+		"%s - accounts of %s":                                "%s - accounts van %s",
+		"No KC for this member yet!":                         "Nog geen KC voor dit lid!",
+
+		// Standings notifications
+		"notifications": "meldingen",
+		"overtake":      "ingehaald",
+		"threshold":     "drempel",
+		"milestone":     "mijlpaal",
+		"delivery":      "bezorging",
+		"Pick which updates about your standing you get":                 "Kies welke updates over je positie je krijgt",
+		"Let me know when someone overtakes me":                          "Laat het me weten als iemand me inhaalt",
+		"Let me know when I reach the threshold":                         "Laat het me weten als ik de drempel haal",
+		"Let me know when I reach a round KC number":                     "Laat het me weten als ik een rond KC-aantal haal",
+		"How to let you know":                                            "Hoe we het je laten weten",
+		"Direct message":                                                 "Privébericht",
+		"Ping in the announcement channel":                               "Vermelding in het aankondigingskanaal",
+		"something went wrong while fetching your notification settings": "er ging iets mis bij het ophalen van je meldingsinstellingen",
+		"something went wrong while saving your notification settings":   "er ging iets mis bij het opslaan van je meldingsinstellingen",
+		"✅ On":                 "✅ Aan",
+		"❌ Off":                "❌ Uit",
+		"🔔 Your notifications": "🔔 Je meldingen",
+		"Change them with the options of `/notifications`.": "Pas ze aan met de opties van `/notifications`.",
+		"Overtaken":         "Ingehaald",
+		"Threshold reached": "Drempel gehaald",
+		"KC milestones":     "KC-mijlpalen",
+		"Delivery":          "Bezorging",
+		"📣 %s event update": "📣 Update van het %s-evenement",
+		"Change what you hear about with /notifications":                                   "Pas met /notifications aan waarover je hoort",
+		"🔻 <@%s> overtook you with `%d` KC, you're at `%d` KC.":                            "🔻 <@%s> heeft je ingehaald met `%d` KC, jij staat op `%d` KC.",
+		"🎯 You reached the threshold of %dkc with `%d` KC, you're on the leaderboard now!": "🎯 Je hebt de drempel van %dkc gehaald met `%d` KC, je staat nu op de ranglijst!",
+		"🏅 You reached `%d` KC!":                                                           "🏅 Je hebt `%d` KC gehaald!",
+
+		// Web dashboard
+		"Misclicked events":                    "Misclicked-evenementen",
+		"Leaderboard":                          "Ranglijst",
+		"Ranking":                              "Klassement",
+		"Past events":                          "Afgelopen evenementen",
+		"There is no event running right now.": "Er loopt op dit moment geen evenement.",
+		"Tracked bosses":                       "Gevolgde bazen",
+		"Threshold: %dkc":                      "Drempel: %dkc",
+		"Member":                               "Lid",
+		"KC":                                   "KC",
+		"Points":                               "Punten",
+		"Last update":                          "Laatste update",
+		"24 hours":                             "24 uur",
+		"Below the threshold":                  "Onder de drempel",
+		"🚨 No participants have enough KC yet!":         "🚨 Nog geen deelnemers hebben genoeg KC!",
+		"No events have ended yet.":                     "Er zijn nog geen evenementen afgelopen.",
+		"Something went wrong while loading this page.": "Er ging iets mis bij het laden van deze pagina.",
+		"⚠️ The OSRS hiscores are unavailable since %s, KC is shown as it was before the outage.": "⚠️ De OSRS-hiscores zijn onbereikbaar sinds %s, de KC is zoals vóór de storing.",
+
+		// Export
+		"export": "exporteren",
+		"event":  "evenement",
+		"Download the full results of an event as CSV and JSON":                                   "Download de volledige resultaten van een evenement als CSV en JSON",
+		"The event to export, the current one if left empty":                                      "Het evenement om te exporteren, het huidige als je het leeg laat",
+		"there is no event running, pick a past event to export":                                  "er loopt geen evenement, kies een afgelopen evenement om te exporteren",
+		"pick an event from the list to export":                                                   "kies een evenement uit de lijst om te exporteren",
+		"something went wrong while building the export":                                          "er ging iets mis bij het maken van de export",
+		"📦 Results of the %s event.":                                                              "📦 Resultaten van het %s-evenement.",
+		"📦 Results of the %s event that started on %s.":                                           "📦 Resultaten van het %s-evenement dat begon op %s.",
+		"_The event is still running, the points are what the ranks would earn if it ended now._": "_Het evenement loopt nog, de punten zijn wat de posities zouden opleveren als het nu zou eindigen._",
+		"%s (running)": "%s (bezig)",
+
+		// Webhooks
+		"webhooks": "webhooks",
+		"add":      "toevoegen",
+		"remove":   "verwijderen",
+		"list":     "lijst",
+		"url":      "url",
+		"Manage the URLs that get competition events":                       "Beheer de URL's die de competitie-gebeurtenissen krijgen",
+		"Send competition events to a URL":                                  "Stuur competitie-gebeurtenissen naar een URL",
+		"The URL to send the events to":                                     "De URL om de gebeurtenissen naar te sturen",
+		"Stop sending competition events to a URL":                          "Stop met het sturen van competitie-gebeurtenissen naar een URL",
+		"The URL to stop sending the events to":                             "De URL om geen gebeurtenissen meer naar te sturen",
+		"Show the URLs that get competition events":                         "Toon de URL's die de competitie-gebeurtenissen krijgen",
+		"please provide a valid http or https URL":                          "geef een geldige http- of https-URL op",
+		"that URL already gets the competition events":                      "die URL krijgt de competitie-gebeurtenissen al",
+		"a server can have at most %d webhooks":                             "een server kan hoogstens %d webhooks hebben",
+		"set up the channels with `/setup-channels` before adding webhooks": "stel eerst de kanalen in met `/setup-channels` voordat je webhooks toevoegt",
+		"⚠️ Accounts tracked by more than one member":                       "⚠️ Accounts die door meerdere leden gevolgd worden",
+		"These accounts count for every member tracking them. Decide who they belong to and remove them from the others with `/admin untrack`:\n\n%s": "Deze accounts tellen mee voor elk lid dat ze volgt. Bepaal van wie ze zijn en haal ze bij de anderen weg met `/admin untrack`:\n\n%s",
+		"webhooks can't point at private or local addresses": "webhooks kunnen niet naar privé- of lokale adressen wijzen",
+		"something went wrong while adding the webhook":      "er ging iets mis bij het toevoegen van de webhook",
+		"✅ Competition events are now sent to <%s>.\n\nEvery delivery has a `%s` header with the HMAC-SHA256 of the body, signed with this secret. Store it somewhere safe, it won't be shown again:\n||`%s`||": "✅ Competitie-gebeurtenissen worden nu naar <%s> gestuurd.\n\nElke levering heeft een `%s`-header met de HMAC-SHA256 van de body, ondertekend met dit geheim. Bewaar het op een veilige plek, het wordt niet nog eens getoond:\n||`%s`||",
+		"that URL doesn't get the competition events":                           "die URL krijgt de competitie-gebeurtenissen niet",
+		"something went wrong while removing the webhook":                       "er ging iets mis bij het verwijderen van de webhook",
+		"✅ Competition events are no longer sent to <%s>.":                      "✅ Competitie-gebeurtenissen worden niet meer naar <%s> gestuurd.",
+		"No URLs get the competition events yet, add one with `/webhooks add`.": "Nog geen URL's krijgen de competitie-gebeurtenissen, voeg er een toe met `/webhooks add`.",
+		"These URLs get the competition events:\n%s":                            "Deze URL's krijgen de competitie-gebeurtenissen:\n%s",
+
+		// Admin account management
+		"admin":    "beheer",
+		"transfer": "overdragen",
+		"to":       "naar",
+		"Manage the tracked accounts of other members":                  "Beheer de gevolgde accounts van andere leden",
+		"The member whose accounts to manage":                           "Het lid wiens accounts je wilt beheren",
+		"Start tracking an OSRS account for a member":                   "Begin met het volgen van een OSRS-account voor een lid",
+		"Stop tracking an OSRS account of a member":                     "Stop met het volgen van een OSRS-account van een lid",
+		"Rename a tracked OSRS account of a member":                     "Hernoem een gevolgd OSRS-account van een lid",
+		"Move an OSRS account and its event progress to another member": "Verplaats een OSRS-account en de voortgang ervan naar een ander lid",
+		"The OSRS account username to move":                             "De gebruikersnaam van het OSRS-account om te verplaatsen",
+		"The member to move the account to":                             "Het lid om het account naar te verplaatsen",
+		"✅ Started tracking **%s** for <@%s>.":                          "✅ **%s** wordt nu gevolgd voor <@%s>.",
+		"✅ Stopped tracking **%s** for <@%s>.":                          "✅ **%s** wordt niet meer gevolgd voor <@%s>.",
+		"✅ Renamed **%s** to **%s** for <@%s>.":                         "✅ **%s** is hernoemd naar **%s** voor <@%s>.",
+		"could not transfer the account '%s': %v":                       "kon het account '%s' niet overdragen: %v",
+		"✅ Moved **%s** from <@%s> to <@%s>.":                           "✅ **%s** is verplaatst van <@%s> naar <@%s>.",
+
+		// Account claims
+		"⚠️ **%s** is already tracked by <@%s>, an account can only count for one member.\n\nIf the account is yours, claim it and an admin will settle who it belongs to.": "⚠️ **%s** wordt al gevolgd door <@%s>, een account telt maar voor één lid.\n\nIs het account van jou? Claim het dan en een beheerder beslist van wie het is.",
+		"Claim this account":   "Claim dit account",
+		"unknown claim button": "onbekende claimknop",
+		"there is no admin channel to send the claim to, please ask an admin directly": "er is geen beheerkanaal om de claim naartoe te sturen, vraag het een beheerder rechtstreeks",
+		"you already claimed this account, an admin will look at it soon":              "je hebt dit account al geclaimd, een beheerder kijkt er snel naar",
+		"could not claim the account: %v":                                              "kon het account niet claimen: %v",
+		"🙋 Account claim":                                                              "🙋 Accountclaim",
+		"<@%s> says **%s** is theirs, but it's tracked by <@%s>.\n\nGiving it to them moves the account and its event progress over.": "<@%s> zegt dat **%s** van hen is, maar het wordt gevolgd door <@%s>.\n\nAls je het aan hen geeft, gaan het account en de voortgang in het evenement mee.",
+		"Give to %s":   "Geef aan %s",
+		"Keep with %s": "Laat bij %s",
+		"could not send the claim to the admin channel, please try again later or ask an admin directly": "kon de claim niet naar het beheerkanaal sturen, probeer het later opnieuw of vraag het een beheerder rechtstreeks",
+		"📨 Your claim on **%s** has been sent to the admins.":                                            "📨 Je claim op **%s** is naar de beheerders gestuurd.",
+		"Kept with the current owner":                                                                    "Bij de huidige eigenaar gelaten",
+		"Given to the claimant":                                                                          "Aan de claimer gegeven",
+		"The admins decided **%s** stays with its current owner.":                                        "De beheerders hebben besloten dat **%s** bij de huidige eigenaar blijft.",
+		"The admins gave you **%s**, it's tracked on your profile now.":                                  "De beheerders hebben je **%s** gegeven, het wordt nu op je profiel gevolgd.",
+
+		// Account verification
+		"verification": "verificatie",
+		"verify":       "verifieer",
+		"required":     "verplicht",
+		"Require members to prove they own an account before its KC counts":                "Laat leden bewijzen dat een account van hen is voordat de KC telt",
+		"Whether newly tracked accounts need to be verified":                               "Of nieuw gevolgde accounts geverifieerd moeten worden",
+		"Prove you own one of your tracked accounts so its KC counts":                      "Bewijs dat een van je gevolgde accounts van jou is zodat de KC telt",
+		"The OSRS account to verify":                                                       "Het OSRS-account om te verifiëren",
+		"set up the channels with `/setup-channels` before changing the verification mode": "stel eerst de kanalen in met `/setup-channels` voordat je de verificatie aanpast",
+		"✅ Verification is off, every tracked account counts. Accounts more than one member tracks without a verification stay unverified until one of them passes `/verify`.": "✅ Verificatie staat uit, elk gevolgd account telt. Accounts die meer dan één lid zonder verificatie volgt, blijven onbevestigd tot een van hen `/verify` haalt.",
+		"✅ Verification is on. Accounts tracked from now on only count once their owner passes a challenge with `/verify`. Accounts that are already tracked keep counting.":   "✅ Verificatie staat aan. Accounts die vanaf nu gevolgd worden tellen pas mee als de eigenaar een uitdaging haalt met `/verify`. Accounts die al gevolgd worden blijven meetellen.",
+		"unknown verification button":                      "onbekende verificatieknop",
+		"✅ **%s** is verified, its KC counts.":             "✅ **%s** is geverifieerd, de KC telt mee.",
+		"🎉 **%s** is verified, its KC counts from now on.": "🎉 **%s** is geverifieerd, de KC telt vanaf nu mee.",
+		"could not verify the account '%s': %v":            "kon het account '%s' niet verifiëren: %v",
+		"🔒 To prove **%s** is yours, gain at least **%d %s** XP on it before <t:%d:t> (<t:%d:R>). Log out afterwards so the hiscores catch up, then press the button below.": "🔒 Om te bewijzen dat **%s** van jou is, haal je er minstens **%d %s**-XP op voor <t:%d:t> (<t:%d:R>). Log daarna uit zodat de hiscores bijwerken en druk dan op de knop hieronder.",
+		"**%s** isn't on the hiscores in any of %s, so a challenge in them wouldn't show up. Train one of them until it's listed, then try again.":                           "**%s** staat in geen van %s op de hiscores, dus een uitdaging daarin zou niet zichtbaar worden. Train er een tot die vermeld wordt en probeer het dan opnieuw.",
+		"⏳ Not enough new %s XP on the hiscores yet.":                                    "⏳ Nog niet genoeg nieuwe %s-XP op de hiscores.",
+		"⏳ The last challenge for **%s** ran out. You can try a new challenge <t:%d:R>.": "⏳ De vorige uitdaging voor **%s** is verlopen. Je kunt <t:%d:R> een nieuwe uitdaging proberen.",
+		"something went wrong while changing the verification mode":                      "er ging iets mis bij het aanpassen van de verificatie",
+		"Check now": "Nu controleren",
+		"🔒 Its KC only counts once you prove it's yours with `/verify`.":        "🔒 De KC telt pas mee als je met `/verify` bewijst dat het account van jou is.",
+		"🔒 **%s**\n   └ *Not verified yet, use `/verify` so its KC counts*\n\n": "🔒 **%s**\n   └ *Nog niet geverifieerd, gebruik `/verify` zodat de KC telt*\n\n",
+
+		// Organizers
+		"organizers": "organisatoren",
+		"role":       "rol",
+		"Manage the roles that start and end events":                                     "Beheer de rollen die evenementen starten en beëindigen",
+		"Let a role start and end events without the event password":                     "Laat een rol evenementen starten en beëindigen zonder het wachtwoord",
+		"The role of the organizers":                                                     "De rol van de organisatoren",
+		"Stop a role from starting and ending events":                                    "Laat een rol geen evenementen meer starten en beëindigen",
+		"The role that no longer organizes events":                                       "De rol die geen evenementen meer organiseert",
+		"Show the roles that start and end events":                                       "Toon de rollen die evenementen starten en beëindigen",
+		"<@&%s> already organizes the events":                                            "<@&%s> organiseert de evenementen al",
+		"set up the channels with `/setup-channels` before adding organizers":            "stel eerst de kanalen in met `/setup-channels` voordat je organisatoren toevoegt",
+		"✅ Members with <@&%s> can now start and end events without the event password.": "✅ Leden met <@&%s> kunnen nu evenementen starten en beëindigen zonder het wachtwoord.",
+		"<@&%s> doesn't organize the events":                                             "<@&%s> organiseert de evenementen niet",
+		"something went wrong while removing the organizer role":                         "er ging iets mis bij het verwijderen van de organisatorrol",
+		"✅ Members with <@&%s> no longer organize the events.":                           "✅ Leden met <@&%s> organiseren de evenementen niet meer.",
+		"Only admins run the events, add organizers with `/organizers add`.":             "Alleen beheerders leiden de evenementen, voeg organisatoren toe met `/organizers add`.",
+		"These roles start and end events:\n%s":                                          "Deze rollen starten en beëindigen evenementen:\n%s",
+	})
+}
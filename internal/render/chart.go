@@ -0,0 +1,167 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+)
+
+// Chart is KC over time, a line per series.
+type Chart struct {
+	Title    string
+	Subtitle string
+	Series   []Series
+	// Empty is shown instead of the plot when there is nothing to draw.
+	Empty string
+}
+
+// Series is a line on a chart, its points sorted by time.
+type Series struct {
+	Name   string
+	Points []ChartPoint
+}
+
+type ChartPoint struct {
+	At    time.Time
+	Value int
+}
+
+const (
+	chartHeight = 360
+	// Room for the labels left of and below the plot
+	axisLabelWidth  = 60
+	axisLabelHeight = 24
+	legendRowHeight = glyphHeight + 10
+)
+
+var seriesColors = []color.Color{
+	gold,
+	color.RGBA{0x57, 0xa6, 0xff, 0xff},
+	color.RGBA{0x3b, 0xd1, 0x6f, 0xff},
+	color.RGBA{0xff, 0x6b, 0x6b, 0xff},
+	color.RGBA{0xc0, 0x84, 0xfc, 0xff},
+	warnColor,
+	color.RGBA{0x2d, 0xd4, 0xbf, 0xff},
+	color.RGBA{0xf4, 0x72, 0xb6, 0xff},
+	silver,
+	bronze,
+}
+
+// RenderChart draws the series as lines over time with a legend below.
+func RenderChart(chart Chart) ([]byte, error) {
+	legendRows := (len(chart.Series) + 1) / 2
+	height := padding + glyphHeight*2 + 8 + glyphHeight + padding + chartHeight + axisLabelHeight + padding +
+		legendRows*legendRowHeight + padding
+
+	c := newCanvas(height)
+
+	y := padding
+	c.text(padding, y, chart.Title, textColor, 2)
+	y += glyphHeight*2 + 8
+	c.text(padding, y, chart.Subtitle, mutedColor, 1)
+	y += glyphHeight + padding
+
+	plot := image.Rect(padding+axisLabelWidth, y, width-padding, y+chartHeight)
+	c.fill(plot, panel)
+
+	start, end, maxValue := chartBounds(chart.Series)
+	if maxValue < 0 {
+		c.centeredText(plot.Min.X+plot.Dx()/2, plot.Min.Y+plot.Dy()/2-glyphHeight, chart.Empty, mutedColor, 2)
+		return c.encode()
+	}
+
+	// Horizontal grid lines at round numbers
+	step := niceStep(maxValue)
+	top := ((maxValue + step - 1) / step) * step
+	if top == 0 {
+		top = step
+	}
+	for value := 0; value <= top; value += step {
+		lineY := plot.Max.Y - value*plot.Dy()/top
+		c.fill(image.Rect(plot.Min.X, lineY, plot.Max.X, lineY+1), background)
+		label := fmt.Sprintf("%d", value)
+		c.text(plot.Min.X-8-textWidth(label, 1), lineY-glyphHeight/2, label, mutedColor, 1)
+	}
+
+	// Dates at the start, middle and end of the time axis
+	span := end.Sub(start)
+	if span <= 0 {
+		span = time.Hour
+	}
+	for _, fraction := range []float64{0, 0.5, 1} {
+		at := start.Add(time.Duration(float64(span) * fraction))
+		label := at.Format("Jan 02 15:04")
+		x := plot.Min.X + int(fraction*float64(plot.Dx()))
+		x = min(max(x-textWidth(label, 1)/2, plot.Min.X), plot.Max.X-textWidth(label, 1))
+		c.text(x, plot.Max.Y+8, label, mutedColor, 1)
+	}
+
+	toPoint := func(point ChartPoint) image.Point {
+		x := plot.Min.X + int(float64(point.At.Sub(start))/float64(span)*float64(plot.Dx()))
+		y := plot.Max.Y - point.Value*plot.Dy()/top
+		return image.Pt(x, y)
+	}
+
+	// Draw the first series last so the leader ends up on top
+	for i := len(chart.Series) - 1; i >= 0; i-- {
+		series := chart.Series[i]
+		col := seriesColors[i%len(seriesColors)]
+		for j, point := range series.Points {
+			current := toPoint(point)
+			if j == 0 {
+				c.dot(current, col, 3)
+				continue
+			}
+			c.line(toPoint(series.Points[j-1]), current, col, 3)
+		}
+	}
+
+	// Legend in two columns
+	y = plot.Max.Y + axisLabelHeight + padding
+	columnWidth := (width - padding*2) / 2
+	for i, series := range chart.Series {
+		x := padding + (i%2)*columnWidth
+		rowY := y + (i/2)*legendRowHeight
+		c.fill(image.Rect(x, rowY+2, x+glyphHeight-4, rowY+glyphHeight-2), seriesColors[i%len(seriesColors)])
+		name := series.Name
+		if len(series.Points) > 0 {
+			name = fmt.Sprintf("%s (%d)", name, series.Points[len(series.Points)-1].Value)
+		}
+		c.text(x+glyphHeight+4, rowY, truncate(name, columnWidth-glyphHeight-12, 1), textColor, 1)
+	}
+
+	return c.encode()
+}
+
+// chartBounds returns the time range and highest value over all series. The
+// highest value is -1 when there are no points at all.
+func chartBounds(series []Series) (time.Time, time.Time, int) {
+	var start, end time.Time
+	maxValue := -1
+	for _, s := range series {
+		for _, point := range s.Points {
+			if start.IsZero() || point.At.Before(start) {
+				start = point.At
+			}
+			if point.At.After(end) {
+				end = point.At
+			}
+			maxValue = max(maxValue, point.Value)
+		}
+	}
+	return start, end, maxValue
+}
+
+// niceStep picks a round step that splits 0 to maxValue in about four parts.
+func niceStep(maxValue int) int {
+	step := 1
+	for {
+		for _, multiplier := range []int{1, 2, 5} {
+			if step*multiplier*4 >= maxValue {
+				return step * multiplier
+			}
+		}
+		step *= 10
+	}
+}
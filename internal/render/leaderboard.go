@@ -0,0 +1,118 @@
+package render
+
+import (
+	"fmt"
+	"image"
+
+	"golang.org/x/text/message"
+)
+
+// maxImageRows keeps the image readable, the embed text still lists everyone.
+const maxImageRows = 25
+
+// Leaderboard is the live KC leaderboard of the current event.
+type Leaderboard struct {
+	Title     string
+	Subtitle  string
+	Thumbnail image.Image
+	Rows      []LeaderboardRow
+	Footer    string
+}
+
+type LeaderboardRow struct {
+	Rank     int
+	Name     string
+	TotalKC  int
+	Points   int
+	Accounts []AccountRow
+}
+
+type AccountRow struct {
+	Name  string
+	KC    int
+	Stale bool
+}
+
+// RenderLeaderboard draws the KC leaderboard: the podium, then every
+// participant with their account breakdown and the points their rank earns.
+// The labels are translated with p.
+func RenderLeaderboard(p *message.Printer, board Leaderboard) ([]byte, error) {
+	rows := board.Rows
+	hidden := 0
+	if len(rows) > maxImageRows {
+		hidden = len(rows) - maxImageRows
+		rows = rows[:maxImageRows]
+	}
+
+	const rowHeight = glyphHeight*2 + 12
+	const accountHeight = glyphHeight + 6
+	height := padding + 80 + padding
+	if len(rows) > 0 {
+		height += 40 + 130 + padding
+	}
+	for _, row := range rows {
+		height += rowHeight + len(row.Accounts)*accountHeight + 8
+	}
+	height += glyphHeight*2 + padding*2
+
+	c := newCanvas(height)
+
+	// Header with the boss thumbnail on the right
+	y := padding
+	c.text(padding, y, board.Title, textColor, 3)
+	c.text(padding, y+glyphHeight*3+10, board.Subtitle, mutedColor, 2)
+	if board.Thumbnail != nil {
+		c.image(image.Rect(width-padding-80, y, width-padding, y+80), board.Thumbnail)
+	}
+	y += 80 + padding
+
+	if len(rows) == 0 {
+		c.text(padding, y, p.Sprintf("No participants have enough KC yet!"), mutedColor, 2)
+	} else {
+		var podium []PodiumEntry
+		for _, row := range rows {
+			if row.Rank > 3 {
+				break
+			}
+			podium = append(podium, PodiumEntry{Rank: row.Rank, Name: row.Name, Value: p.Sprintf("%d KC", row.TotalKC)})
+		}
+		y = c.podium(y, podium)
+	}
+
+	for i, row := range rows {
+		rowTop := y
+		rowBottom := y + rowHeight + len(row.Accounts)*accountHeight
+		if i%2 == 0 {
+			c.fill(image.Rect(padding, rowTop, width-padding, rowBottom), panel)
+		}
+
+		c.fill(image.Rect(padding, rowTop, padding+6, rowBottom), podiumColor(row.Rank))
+		c.text(padding+16, y+6, fmt.Sprintf("%d.", row.Rank), textColor, 2)
+		c.text(padding+80, y+6, truncate(row.Name, 380, 2), textColor, 2)
+
+		kc := p.Sprintf("%d KC", row.TotalKC)
+		points := p.Sprintf("+%d pts", row.Points)
+		c.text(width-padding-16-textWidth(points, 1), y+12, points, mutedColor, 1)
+		c.text(width-padding-120-textWidth(kc, 2), y+6, kc, textColor, 2)
+		y += rowHeight
+
+		for _, account := range row.Accounts {
+			line := fmt.Sprintf("- %s: %d", account.Name, account.KC)
+			col := mutedColor
+			if account.Stale {
+				line += p.Sprintf(" (stale)")
+				col = warnColor
+			}
+			c.text(padding+96, y, truncate(line, width-padding*2-112, 1), col, 1)
+			y += accountHeight
+		}
+		y += 8
+	}
+
+	if hidden > 0 {
+		c.text(padding, y+8, p.Sprintf("... and %d more", hidden), mutedColor, 1)
+	}
+	c.text(padding, height-padding-glyphHeight, board.Footer, mutedColor, 1)
+
+	return c.encode()
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"fmt"
+	"image"
+
+	"golang.org/x/text/message"
+)
+
+// RankingRow is a participant in the overall ranking.
+type RankingRow struct {
+	Rank   int
+	Name   string
+	Points int
+}
+
+// RenderRanking draws the overall competition ranking: the podium followed
+// by everyone else with their point totals. The labels are translated with p.
+func RenderRanking(p *message.Printer, title string, rows []RankingRow) ([]byte, error) {
+	shown := rows
+	hidden := 0
+	if len(shown) > maxImageRows*2 {
+		hidden = len(shown) - maxImageRows*2
+		shown = shown[:maxImageRows*2]
+	}
+
+	const rowHeight = glyphHeight*2 + 14
+	height := padding + glyphHeight*3 + padding
+	if len(shown) > 0 {
+		height += 40 + 130 + padding
+	}
+	height += len(shown)*rowHeight + glyphHeight*2 + padding*2
+
+	c := newCanvas(height)
+
+	y := padding
+	c.text(padding, y, title, textColor, 3)
+	y += glyphHeight*3 + padding
+
+	if len(shown) == 0 {
+		c.text(padding, y, p.Sprintf("Participants don't have any points yet!"), mutedColor, 2)
+		return c.encode()
+	}
+
+	var podium []PodiumEntry
+	for _, row := range shown {
+		if row.Rank > 3 {
+			break
+		}
+		podium = append(podium, PodiumEntry{Rank: row.Rank, Name: row.Name, Value: p.Sprintf("%d pts", row.Points)})
+	}
+	y = c.podium(y, podium)
+
+	for i, row := range shown {
+		if i%2 == 0 {
+			c.fill(image.Rect(padding, y, width-padding, y+rowHeight), panel)
+		}
+		c.fill(image.Rect(padding, y, padding+6, y+rowHeight), podiumColor(row.Rank))
+		c.text(padding+16, y+7, fmt.Sprintf("%d.", row.Rank), textColor, 2)
+		c.text(padding+80, y+7, truncate(row.Name, 480, 2), textColor, 2)
+
+		points := p.Sprintf("%d pts", row.Points)
+		c.text(width-padding-16-textWidth(points, 2), y+7, points, textColor, 2)
+		y += rowHeight
+	}
+
+	if hidden > 0 {
+		c.text(padding, y+8, p.Sprintf("... and %d more", hidden), mutedColor, 1)
+	}
+
+	return c.encode()
+}
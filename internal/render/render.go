@@ -0,0 +1,233 @@
+// Package render draws the leaderboards as PNG images. It only uses the
+// embedded Go Mono font, so it runs anywhere without font files.
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	width   = 800
+	padding = 24
+	// The font is monospaced at 7x14 pixels, everything is drawn at a
+	// multiple of it.
+	glyphWidth  = 7
+	glyphHeight = 14
+	fontSize    = 11
+)
+
+var (
+	// face covers Latin-1 and Latin Extended, unlike the ASCII-only bitmap
+	// fonts, so accented display names don't turn into boxes. Faces aren't
+	// safe for concurrent use.
+	face   = newFace()
+	faceMu sync.Mutex
+)
+
+func newFace() font.Face {
+	parsed, err := opentype.Parse(gomono.TTF)
+	if err != nil {
+		panic(fmt.Sprintf("failed to parse the embedded font: %v", err))
+	}
+
+	// Full hinting keeps every advance at a whole glyphWidth
+	face, err := opentype.NewFace(parsed, &opentype.FaceOptions{
+		Size:    fontSize,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to create the font face: %v", err))
+	}
+	return face
+}
+
+var (
+	background = color.RGBA{0x2b, 0x2d, 0x31, 0xff}
+	panel      = color.RGBA{0x31, 0x33, 0x38, 0xff}
+	textColor  = color.RGBA{0xf2, 0xf3, 0xf5, 0xff}
+	mutedColor = color.RGBA{0x9b, 0xa0, 0xa8, 0xff}
+	warnColor  = color.RGBA{0xff, 0xa5, 0x00, 0xff}
+	gold       = color.RGBA{0xff, 0xd7, 0x00, 0xff}
+	silver     = color.RGBA{0xc0, 0xc0, 0xc0, 0xff}
+	bronze     = color.RGBA{0xcd, 0x7f, 0x32, 0xff}
+)
+
+// canvas is an image being drawn top to bottom.
+type canvas struct {
+	img *image.RGBA
+}
+
+func newCanvas(height int) *canvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	return &canvas{img: img}
+}
+
+func (c *canvas) fill(rect image.Rectangle, col color.Color) {
+	draw.Draw(c.img, rect, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+// dot draws a square of size by size pixels centered on p.
+func (c *canvas) dot(p image.Point, col color.Color, size int) {
+	c.fill(image.Rect(p.X-size/2, p.Y-size/2, p.X-size/2+size, p.Y-size/2+size), col)
+}
+
+// line draws a line from a to b, thickness pixels wide.
+func (c *canvas) line(a, b image.Point, col color.Color, thickness int) {
+	dx := abs(b.X - a.X)
+	dy := -abs(b.Y - a.Y)
+	stepX, stepY := 1, 1
+	if a.X > b.X {
+		stepX = -1
+	}
+	if a.Y > b.Y {
+		stepY = -1
+	}
+
+	// Bresenham, with a dot at every step for the thickness
+	err := dx + dy
+	for {
+		c.dot(a, col, thickness)
+		if a == b {
+			return
+		}
+		double := err * 2
+		if double >= dy {
+			err += dy
+			a.X += stepX
+		}
+		if double <= dx {
+			err += dx
+			a.Y += stepY
+		}
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// text draws s with its top-left corner at x, y, scaled up by scale.
+func (c *canvas) text(x, y int, s string, col color.Color, scale int) {
+	if s == "" {
+		return
+	}
+
+	// Draw at the font's own size first, then scale without smoothing so the
+	// pixels stay sharp.
+	small := image.NewRGBA(image.Rect(0, 0, textWidth(s, 1), glyphHeight))
+	faceMu.Lock()
+	drawer := &font.Drawer{
+		Dst:  small,
+		Src:  image.NewUniform(col),
+		Face: face,
+		Dot:  fixed.Point26_6{Y: face.Metrics().Ascent},
+	}
+	drawer.DrawString(s)
+	faceMu.Unlock()
+
+	target := image.Rect(x, y, x+small.Bounds().Dx()*scale, y+glyphHeight*scale)
+	draw.NearestNeighbor.Scale(c.img, target, small, small.Bounds(), draw.Over, nil)
+}
+
+// centeredText draws s centered horizontally on centerX.
+func (c *canvas) centeredText(centerX, y int, s string, col color.Color, scale int) {
+	c.text(centerX-textWidth(s, scale)/2, y, s, col, scale)
+}
+
+// image draws img scaled into rect.
+func (c *canvas) image(rect image.Rectangle, img image.Image) {
+	draw.CatmullRom.Scale(c.img, rect, img, img.Bounds(), draw.Over, nil)
+}
+
+func (c *canvas) encode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, c.img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func textWidth(s string, scale int) int {
+	return len([]rune(s)) * glyphWidth * scale
+}
+
+// truncate shortens s to fit in maxWidth pixels at the given scale.
+func truncate(s string, maxWidth, scale int) string {
+	maxRunes := maxWidth / (glyphWidth * scale)
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	if maxRunes <= 3 {
+		return string(runes[:max(0, maxRunes)])
+	}
+	return strings.TrimSpace(string(runes[:maxRunes-3])) + "..."
+}
+
+// podiumColor returns the medal color for the top three ranks.
+func podiumColor(rank int) color.Color {
+	switch rank {
+	case 1:
+		return gold
+	case 2:
+		return silver
+	case 3:
+		return bronze
+	default:
+		return panel
+	}
+}
+
+// PodiumEntry is someone on the podium, Value is shown under their name.
+type PodiumEntry struct {
+	Rank  int
+	Name  string
+	Value string
+}
+
+// podium draws the top three with first place in the middle and returns the
+// y position right below it.
+func (c *canvas) podium(y int, entries []PodiumEntry) int {
+	if len(entries) == 0 {
+		return y
+	}
+
+	const columnWidth = 200
+	const nameSpace = 40
+	heights := []int{130, 100, 80}
+	// First place stands in the middle, second on the left and third on the right
+	centers := []int{width / 2, width/2 - columnWidth - 10, width/2 + columnWidth + 10}
+	bottom := y + nameSpace + heights[0]
+
+	for place, entry := range entries {
+		if place >= 3 {
+			break
+		}
+		centerX := centers[place]
+
+		top := bottom - heights[place]
+		c.fill(image.Rect(centerX-columnWidth/2+8, top, centerX+columnWidth/2-8, bottom), podiumColor(entry.Rank))
+		c.centeredText(centerX, top-nameSpace+4, truncate(entry.Name, columnWidth-8, 2), textColor, 2)
+		c.centeredText(centerX, top+12, fmt.Sprintf("#%d", entry.Rank), background, 3)
+		c.centeredText(centerX, top+12+glyphHeight*3+8, truncate(entry.Value, columnWidth-24, 1), background, 1)
+	}
+
+	return bottom + padding
+}
@@ -0,0 +1,53 @@
+package render
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+func TestTextCoversLatinExtended(t *testing.T) {
+	for _, s := range []string{"A", "é", "Ø", "ł", "Ş"} {
+		c := newCanvas(glyphHeight)
+		c.text(0, 0, s, textColor, 1)
+
+		drawn := 0
+		for y := 0; y < glyphHeight; y++ {
+			for x := 0; x < glyphWidth; x++ {
+				if c.img.RGBAAt(x, y) != background {
+					drawn++
+				}
+			}
+		}
+		if drawn == 0 {
+			t.Errorf("%q drew nothing", s)
+		}
+	}
+}
+
+func TestRenderLeaderboard(t *testing.T) {
+	data, err := RenderLeaderboard(message.NewPrinter(language.English), Leaderboard{
+		Title:    "Zulrah Leaderboard",
+		Subtitle: "Threshold 25kc",
+		Rows: []LeaderboardRow{
+			{Rank: 1, Name: "Zoë", TotalKC: 40, Points: 12, Accounts: []AccountRow{{Name: "Zezima", KC: 40}}},
+			{Rank: 2, Name: "Łukasz", TotalKC: 30, Points: 9},
+			{Rank: 2, Name: "Søren", TotalKC: 30, Points: 9, Accounts: []AccountRow{{Name: "Gone", Stale: true}}},
+		},
+		Footer: "Updated just now",
+	})
+	if err != nil {
+		t.Fatalf("RenderLeaderboard: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("not a PNG: %v", err)
+	}
+	if img.Bounds().Dx() != width {
+		t.Errorf("image is %d wide, want %d", img.Bounds().Dx(), width)
+	}
+}
@@ -0,0 +1,50 @@
+package render
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	thumbnailsMu sync.Mutex
+	thumbnails   = map[string]image.Image{}
+	httpClient   = &http.Client{Timeout: 10 * time.Second}
+)
+
+// FetchImage downloads and decodes the image at url. Images are cached, boss
+// thumbnails don't change.
+func FetchImage(url string) (image.Image, error) {
+	thumbnailsMu.Lock()
+	img, ok := thumbnails[url]
+	thumbnailsMu.Unlock()
+	if ok {
+		return img, nil
+	}
+
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received non-200 response: %d", resp.StatusCode)
+	}
+
+	img, _, err = image.Decode(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	thumbnailsMu.Lock()
+	thumbnails[url] = img
+	thumbnailsMu.Unlock()
+
+	return img, nil
+}
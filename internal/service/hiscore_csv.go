@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// csvSkillNames lists the skills in the order index_lite.ws returns them.
+// Keep this in sync with the "skills" array of index_lite.json.
+var csvSkillNames = []string{
+	"Overall",
+	"Attack",
+	"Defence",
+	"Strength",
+	"Hitpoints",
+	"Ranged",
+	"Prayer",
+	"Magic",
+	"Cooking",
+	"Woodcutting",
+	"Fletching",
+	"Fishing",
+	"Firemaking",
+	"Crafting",
+	"Smithing",
+	"Mining",
+	"Herblore",
+	"Agility",
+	"Thieving",
+	"Slayer",
+	"Farming",
+	"Runecraft",
+	"Hunter",
+	"Construction",
+	"Sailing",
+}
+
+// csvActivityNames lists the activities in the order index_lite.ws returns them.
+// Keep this in sync with the "activities" array of index_lite.json, the names
+// have to match exactly since FindActivity matches on them.
+var csvActivityNames = []string{
+	"League Points",
+	"Deadman Points",
+	"Bounty Hunter - Hunter",
+	"Bounty Hunter - Rogue",
+	"Bounty Hunter (Legacy) - Hunter",
+	"Bounty Hunter (Legacy) - Rogue",
+	"Clue Scrolls (all)",
+	"Clue Scrolls (beginner)",
+	"Clue Scrolls (easy)",
+	"Clue Scrolls (medium)",
+	"Clue Scrolls (hard)",
+	"Clue Scrolls (elite)",
+	"Clue Scrolls (master)",
+	"LMS - Rank",
+	"PvP Arena - Rank",
+	"Soul Wars Zeal",
+	"Rifts closed",
+	"Colosseum Glory",
+	"Collections Logged",
+	"Abyssal Sire",
+	"Alchemical Hydra",
+	"Amoxliatl",
+	"Araxxor",
+	"Artio",
+	"Barrows Chests",
+	"Bryophyta",
+	"Callisto",
+	"Cal'varion",
+	"Cerberus",
+	"Chambers of Xeric",
+	"Chambers of Xeric: Challenge Mode",
+	"Chaos Elemental",
+	"Chaos Fanatic",
+	"Commander Zilyana",
+	"Corporeal Beast",
+	"Crazy Archaeologist",
+	"Dagannoth Prime",
+	"Dagannoth Rex",
+	"Dagannoth Supreme",
+	"Deranged Archaeologist",
+	"Doom of Mokhaiotl",
+	"Duke Sucellus",
+	"General Graardor",
+	"Giant Mole",
+	"Grotesque Guardians",
+	"Hespori",
+	"Kalphite Queen",
+	"King Black Dragon",
+	"Kraken",
+	"Kree'Arra",
+	"K'ril Tsutsaroth",
+	"Lunar Chests",
+	"Mimic",
+	"Nex",
+	"Nightmare",
+	"Phosani's Nightmare",
+	"Obor",
+	"Phantom Muspah",
+	"Sarachnis",
+	"Scorpia",
+	"Scurrius",
+	"Skotizo",
+	"Sol Heredit",
+	"Spindel",
+	"Tempoross",
+	"The Gauntlet",
+	"The Corrupted Gauntlet",
+	"The Hueycoatl",
+	"The Leviathan",
+	"The Royal Titans",
+	"The Whisperer",
+	"Theatre of Blood",
+	"Theatre of Blood: Hard Mode",
+	"Thermonuclear Smoke Devil",
+	"Tombs of Amascut",
+	"Tombs of Amascut: Expert Mode",
+	"TzKal-Zuk",
+	"TzTok-Jad",
+	"Vardorvis",
+	"Venenatis",
+	"Vet'ion",
+	"Vorkath",
+	"Wintertodt",
+	"Yama",
+	"Zalcano",
+	"Zulrah",
+}
+
+// SkillNames returns the skill names in hiscore order.
+func SkillNames() []string {
+	return slices.Clone(csvSkillNames)
+}
+
+// ActivityNames returns the activity names in hiscore order.
+func ActivityNames() []string {
+	return slices.Clone(csvActivityNames)
+}
+
+// parseHiscoreCSV parses the index_lite.ws format into skills and activities.
+// Skill lines are "rank,level,xp" and activity lines are "rank,score", both in
+// the order of the name tables above. Jagex adds new skills and activities at
+// the end, so lines past the tables are ignored. Fewer lines than the tables
+// means the format has changed and we refuse to guess which line is which.
+func parseHiscoreCSV(r io.Reader) ([]Skill, []Activity, error) {
+	var skills []Skill
+	var activities []Activity
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		values := make([]int, len(fields))
+		for i, field := range fields {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse hiscore CSV line %q: %w", line, err)
+			}
+			values[i] = value
+		}
+
+		switch len(values) {
+		case 3:
+			if len(activities) > 0 {
+				return nil, nil, fmt.Errorf("unexpected skill line after activities: %q", line)
+			}
+			skills = append(skills, Skill{
+				ID:    len(skills),
+				Rank:  values[0],
+				Level: values[1],
+				XP:    values[2],
+			})
+		case 2:
+			activities = append(activities, Activity{
+				ID:    len(activities),
+				Rank:  values[0],
+				Score: values[1],
+			})
+		default:
+			return nil, nil, fmt.Errorf("unexpected hiscore CSV line: %q", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read hiscore CSV: %w", err)
+	}
+
+	if len(skills) < len(csvSkillNames) {
+		return nil, nil, fmt.Errorf("hiscore CSV has %d skills, expected %d", len(skills), len(csvSkillNames))
+	}
+	if len(activities) < len(csvActivityNames) {
+		return nil, nil, fmt.Errorf("hiscore CSV has %d activities, expected %d", len(activities), len(csvActivityNames))
+	}
+	skills = skills[:len(csvSkillNames)]
+	activities = activities[:len(csvActivityNames)]
+
+	for i := range skills {
+		skills[i].Name = csvSkillNames[i]
+	}
+	for i := range activities {
+		activities[i].Name = csvActivityNames[i]
+	}
+
+	return skills, activities, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// hiscoreCSV builds an index_lite.ws response with every skill at level 1 and
+// every activity unranked, except for the given activity scores.
+func hiscoreCSV(scores map[string]int) string {
+	var b strings.Builder
+	for range csvSkillNames {
+		b.WriteString("-1,1,-1\n")
+	}
+	for _, name := range csvActivityNames {
+		if score, ok := scores[name]; ok {
+			fmt.Fprintf(&b, "1,%d\n", score)
+		} else {
+			b.WriteString("-1,-1\n")
+		}
+	}
+	return b.String()
+}
+
+func TestParseHiscoreCSV(t *testing.T) {
+	skills, activities, err := parseHiscoreCSV(strings.NewReader(hiscoreCSV(map[string]int{"Zulrah": 123})))
+	if err != nil {
+		t.Fatalf("parseHiscoreCSV: %v", err)
+	}
+
+	if len(skills) != len(csvSkillNames) || len(activities) != len(csvActivityNames) {
+		t.Fatalf("got %d skills and %d activities", len(skills), len(activities))
+	}
+
+	attack, ok := FindSkill(skills, "Attack")
+	if !ok || attack.Level != 1 || attack.XP != -1 {
+		t.Errorf("Attack = %+v", attack)
+	}
+
+	zulrah, ok := FindActivity(activities, "Zulrah")
+	if !ok || zulrah.Score != 123 || zulrah.Rank != 1 {
+		t.Errorf("Zulrah = %+v", zulrah)
+	}
+	if activities[zulrah.ID].Name != "Zulrah" {
+		t.Errorf("Zulrah has ID %d, which is %s", zulrah.ID, activities[zulrah.ID].Name)
+	}
+}
+
+func TestParseHiscoreCSVRejectsChangedFormat(t *testing.T) {
+	valid := hiscoreCSV(nil)
+	lines := strings.Split(strings.TrimSpace(valid), "\n")
+
+	tests := map[string]string{
+		"missing activity":     strings.Join(lines[:len(lines)-1], "\n"),
+		"missing skill":        strings.Join(lines[1:], "\n"),
+		"skill after activity": valid + "-1,1,-1\n",
+		"not a number":         strings.Replace(valid, "-1,1,-1", "-1,one,-1", 1),
+		"too many fields":      valid + "1,2,3,4\n",
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := parseHiscoreCSV(strings.NewReader(input)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseHiscoreCSVIgnoresNewEntries(t *testing.T) {
+	valid := hiscoreCSV(map[string]int{"Zulrah": 123})
+	skillLines := strings.Repeat("-1,1,-1\n", len(csvSkillNames))
+
+	tests := map[string]string{
+		"new activity": valid + "5,42\n",
+		"new skill":    skillLines + "7,99,13034431\n" + strings.TrimPrefix(valid, skillLines),
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			skills, activities, err := parseHiscoreCSV(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("parseHiscoreCSV: %v", err)
+			}
+			if len(skills) != len(csvSkillNames) || len(activities) != len(csvActivityNames) {
+				t.Fatalf("got %d skills and %d activities", len(skills), len(activities))
+			}
+			if zulrah, _ := FindActivity(activities, "Zulrah"); zulrah.Score != 123 {
+				t.Errorf("Zulrah = %+v, want the known line", zulrah)
+			}
+		})
+	}
+}
+
+func TestParseHiscoreCSVSkipsBlankLines(t *testing.T) {
+	input := "\n" + strings.ReplaceAll(hiscoreCSV(nil), "\n", "\n\n")
+	if _, _, err := parseHiscoreCSV(strings.NewReader(input)); err != nil {
+		t.Errorf("parseHiscoreCSV: %v", err)
+	}
+}
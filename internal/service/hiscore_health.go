@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// unavailableAfterFailures is the number of failed requests in a row
+	// after which the hiscores are considered down.
+	unavailableAfterFailures = 5
+	// initialBackoff is shorter than the hourly update, so the first update
+	// after the hiscores went down always tries them again.
+	initialBackoff = 30 * time.Minute
+	maxBackoff     = 8 * time.Hour
+	// attemptSlack lets an update that fires just before the backoff ends
+	// through, instead of waiting a whole hour for the next one.
+	attemptSlack = 5 * time.Minute
+)
+
+// ErrHiscoresUnavailable is returned when a run of requests failed and the
+// hiscores are considered down.
+var ErrHiscoresUnavailable = errors.New("OSRS hiscores are unavailable")
+
+// HealthStatus describes whether the hiscores are currently reachable.
+type HealthStatus struct {
+	Available        bool
+	UnavailableSince time.Time
+	LastSuccess      time.Time
+}
+
+type healthTracker struct {
+	mu                  sync.Mutex
+	consecutiveFailures int
+	firstFailure        time.Time
+	unavailableSince    time.Time
+	lastSuccess         time.Time
+	nextAttempt         time.Time
+	backoff             time.Duration
+}
+
+var health = &healthTracker{}
+
+func (h *healthTracker) recordSuccess() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.consecutiveFailures = 0
+	h.unavailableSince = time.Time{}
+	h.nextAttempt = time.Time{}
+	h.backoff = 0
+	h.lastSuccess = time.Now()
+}
+
+func (h *healthTracker) recordFailure() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.consecutiveFailures == 0 {
+		h.firstFailure = time.Now()
+	}
+	h.consecutiveFailures++
+
+	if h.unavailableSince.IsZero() && h.consecutiveFailures >= unavailableAfterFailures {
+		h.unavailableSince = h.firstFailure
+		h.backoff = initialBackoff
+		h.nextAttempt = time.Now().Add(h.backoff)
+	}
+}
+
+// ResetHealth forgets every failure and success, as if no request was made
+// yet. Tests use it so one test's outage doesn't carry over into the next.
+func ResetHealth() {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	health.consecutiveFailures = 0
+	health.firstFailure = time.Time{}
+	health.unavailableSince = time.Time{}
+	health.lastSuccess = time.Time{}
+	health.nextAttempt = time.Time{}
+	health.backoff = 0
+}
+
+// Health returns the current state of the hiscores.
+func Health() HealthStatus {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	return HealthStatus{
+		Available:        health.unavailableSince.IsZero(),
+		UnavailableSince: health.unavailableSince,
+		LastSuccess:      health.lastSuccess,
+	}
+}
+
+// ShouldAttemptUpdate reports whether an update cycle should run. While the
+// hiscores are down it only allows a cycle once the backoff has passed, and
+// doubles the backoff for the next one in case they're still down.
+func ShouldAttemptUpdate() bool {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	if health.unavailableSince.IsZero() {
+		return true
+	}
+
+	if time.Now().Add(attemptSlack).Before(health.nextAttempt) {
+		return false
+	}
+
+	health.backoff = min(health.backoff*2, maxBackoff)
+	health.nextAttempt = time.Now().Add(health.backoff)
+	return true
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHourlyUpdatesRetryAfterAnOutage(t *testing.T) {
+	ResetHealth()
+	t.Cleanup(ResetHealth)
+
+	for range unavailableAfterFailures {
+		health.recordFailure()
+	}
+	if Health().Available {
+		t.Fatal("hiscores still available after a run of failures")
+	}
+
+	// The next hourly update comes after the first backoff
+	if wait := time.Until(health.nextAttempt); wait >= time.Hour {
+		t.Errorf("first retry in %s, want it before the next hourly update", wait)
+	}
+
+	// An update firing just before the backoff ends still gets through
+	health.nextAttempt = time.Now().Add(time.Second)
+	if !ShouldAttemptUpdate() {
+		t.Error("update right before the backoff ended was skipped")
+	}
+	if health.backoff != 2*initialBackoff {
+		t.Errorf("backoff = %s after a retry, want it doubled", health.backoff)
+	}
+
+	if ShouldAttemptUpdate() {
+		t.Error("update allowed again straight after a retry")
+	}
+}
@@ -2,10 +2,25 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
+const defaultHiscoreBaseURL = "https://secure.runescape.com/m=hiscore_oldschool"
+
+var hiscoreBaseURL = defaultHiscoreBaseURL
+
+// SetBaseURL points the hiscore client at a different server, e.g. a local
+// fake hiscore server. An empty url restores the official hiscores.
+func SetBaseURL(url string) {
+	if url == "" {
+		url = defaultHiscoreBaseURL
+	}
+	hiscoreBaseURL = strings.TrimSuffix(url, "/")
+}
+
 // Skill represents a single skill object.
 type Skill struct {
 	ID    int    `json:"id"`
@@ -23,8 +38,11 @@ type Activity struct {
 	Score int    `json:"score"`
 }
 
+// ErrPlayerNotFound is returned when the hiscores don't know the username.
+var ErrPlayerNotFound = errors.New("player not found on the hiscores")
+
 func CheckIfPlayerExists(username string) bool {
-	url := fmt.Sprintf("https://secure.runescape.com/m=hiscore_oldschool/index_lite.ws?player=%s", username)
+	url := fmt.Sprintf("%s/index_lite.ws?player=%s", hiscoreBaseURL, username)
 	resp, err := http.Get(url)
 	if err != nil {
 		return false
@@ -36,16 +54,52 @@ func CheckIfPlayerExists(username string) bool {
 	return false
 }
 
+// FetchHiscore fetches the skills and activities for the given username.
+// It uses the JSON endpoint and falls back to the CSV endpoint when the JSON
+// one fails or returns something that can't be decoded.
+//
+// Every result feeds the health tracker, see Health.
 func FetchHiscore(username string) ([]Skill, []Activity, error) {
-	url := fmt.Sprintf("https://secure.runescape.com/m=hiscore_oldschool/index_lite.json?player=%s", username)
+	skills, activities, err := fetchHiscoreJSON(username)
+	if err == nil {
+		health.recordSuccess()
+		return skills, activities, nil
+	}
+
+	// A missing player is missing on both endpoints, no need to ask twice.
+	if errors.Is(err, ErrPlayerNotFound) {
+		health.recordSuccess()
+		return nil, nil, err
+	}
+
+	skills, activities, csvErr := fetchHiscoreCSV(username)
+	if csvErr != nil {
+		if errors.Is(csvErr, ErrPlayerNotFound) {
+			health.recordSuccess()
+			return nil, nil, csvErr
+		}
+
+		health.recordFailure()
+		if !Health().Available {
+			return nil, nil, fmt.Errorf("%w: %v (csv fallback: %v)", ErrHiscoresUnavailable, err, csvErr)
+		}
+		return nil, nil, fmt.Errorf("%w (csv fallback: %v)", err, csvErr)
+	}
+
+	health.recordSuccess()
+	return skills, activities, nil
+}
+
+func fetchHiscoreJSON(username string) ([]Skill, []Activity, error) {
+	url := fmt.Sprintf("%s/index_lite.json?player=%s", hiscoreBaseURL, username)
 	resp, err := http.Get(url)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to fetch hiscore data: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("received non-200 response: %d", resp.StatusCode)
+	if err := checkStatus(resp); err != nil {
+		return nil, nil, err
 	}
 
 	// Temporary struct matching the JSON structure.
@@ -59,9 +113,39 @@ func FetchHiscore(username string) ([]Skill, []Activity, error) {
 		return nil, nil, fmt.Errorf("failed to decode hiscore JSON: %w", err)
 	}
 
+	if len(data.Skills) == 0 && len(data.Activities) == 0 {
+		return nil, nil, fmt.Errorf("hiscore JSON contained no skills or activities")
+	}
+
 	return data.Skills, data.Activities, nil
 }
 
+func fetchHiscoreCSV(username string) ([]Skill, []Activity, error) {
+	url := fmt.Sprintf("%s/index_lite.ws?player=%s", hiscoreBaseURL, username)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch hiscore data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return nil, nil, err
+	}
+
+	return parseHiscoreCSV(resp.Body)
+}
+
+func checkStatus(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return ErrPlayerNotFound
+	default:
+		return fmt.Errorf("received non-200 response: %d", resp.StatusCode)
+	}
+}
+
 // FindSkill searches for a skill by its name in the provided slice.
 // It returns a pointer to the found Skill and true if found; otherwise, nil and false.
 func FindSkill(skills []Skill, name string) (*Skill, bool) {
@@ -83,3 +167,16 @@ func FindActivity(activities []Activity, name string) (*Activity, bool) {
 	}
 	return nil, false
 }
+
+// MissingActivities returns the names that don't appear in the activities.
+// The hiscores list every activity, ranked or not, so a missing name means
+// it was renamed or removed.
+func MissingActivities(activities []Activity, names []string) []string {
+	var missing []string
+	for _, name := range names {
+		if _, exists := FindActivity(activities, name); !exists {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
@@ -1,7 +1,8 @@
 package utils
 
 import (
-	"fmt"
+	"errors"
+	"misclicked-events/internal/i18n"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -12,12 +13,14 @@ type MessageOptions struct {
 	Color       int
 }
 
-// sendMessage is a helper function to handle common message sending logic
-func sendMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content string, opts MessageOptions) {
+// messageEmbed builds the embed the message is shown in
+func messageEmbed(guildID, content string, opts MessageOptions) *discordgo.MessageEmbed {
+	p := i18n.ForGuild(guildID)
+
 	var color int
 	if opts.IsError {
 		color = 0xff0000 // Red for errors
-		content = fmt.Sprintf("⚠️ **Error**\n%s", content)
+		content = p.Sprintf("⚠️ **Error**\n%s", content)
 	} else {
 		if opts.Color != 0 {
 			color = opts.Color
@@ -32,11 +35,16 @@ func sendMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content s
 	}
 
 	if opts.IsError {
-		embed.Title = "An Error Occurred"
+		embed.Title = p.Sprintf("An Error Occurred")
 	}
 
+	return embed
+}
+
+// sendMessage is a helper function to handle common message sending logic
+func sendMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content string, opts MessageOptions) {
 	data := &discordgo.InteractionResponseData{
-		Embeds: []*discordgo.MessageEmbed{embed},
+		Embeds: []*discordgo.MessageEmbed{messageEmbed(i.GuildID, content, opts)},
 	}
 
 	if opts.IsEphemeral {
@@ -54,30 +62,39 @@ func sendMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content s
 
 // editMessage is a helper function to handle common message editing logic
 func editMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content string, opts MessageOptions) {
+	edit := &discordgo.WebhookEdit{
+		Content: &content,
+	}
+
+	// Errors are shown in the same embed as when they're sent right away
 	if opts.IsError {
-		content = fmt.Sprintf("⚠️ **Error**\n%s", content)
+		empty := ""
+		edit = &discordgo.WebhookEdit{
+			Content: &empty,
+			Embeds:  &[]*discordgo.MessageEmbed{messageEmbed(i.GuildID, content, opts)},
+		}
 	}
 
-	_, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Content: &content,
-	})
+	_, err := s.InteractionResponseEdit(i.Interaction, edit)
 	if err != nil {
 		LogError("Error editing response", err)
 	}
 }
 
-// RespondWithPrivateMessage sends a private ephemeral embedded message
+// RespondWithPrivateMessage sends a private ephemeral embedded message, the
+// message is translated to the guild's locale
 func RespondWithPrivateMessage(s *discordgo.Session, i *discordgo.InteractionCreate, message string, args ...interface{}) {
-	content := fmt.Sprintf(message, args...)
+	content := i18n.ForGuild(i.GuildID).Sprintf(message, args...)
 	sendMessage(s, i, content, MessageOptions{
 		IsEphemeral: true,
 		Color:       0x00ccff,
 	})
 }
 
-// RespondWithMessage sends a public embedded message
+// RespondWithMessage sends a public embedded message, the message is
+// translated to the guild's locale
 func RespondWithMessage(s *discordgo.Session, i *discordgo.InteractionCreate, message string, args ...interface{}) {
-	content := fmt.Sprintf(message, args...)
+	content := i18n.ForGuild(i.GuildID).Sprintf(message, args...)
 	sendMessage(s, i, content, MessageOptions{
 		IsEphemeral: false,
 		Color:       0x33cc33,
@@ -87,7 +104,7 @@ func RespondWithMessage(s *discordgo.Session, i *discordgo.InteractionCreate, me
 // RespondWithError sends a private ephemeral error embedded message
 func RespondWithError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
 	if err == nil {
-		err = fmt.Errorf("unknown error occurred")
+		err = errors.New(i18n.ForGuild(i.GuildID).Sprintf("unknown error occurred"))
 	}
 	sendMessage(s, i, err.Error(), MessageOptions{
 		IsEphemeral: true,
@@ -103,7 +120,7 @@ func EditResponseMessage(s *discordgo.Session, i *discordgo.InteractionCreate, c
 // EditResponseError edits an existing response with an error message
 func EditResponseError(s *discordgo.Session, i *discordgo.InteractionCreate, err error) {
 	if err == nil {
-		err = fmt.Errorf("unknown error occurred")
+		err = errors.New(i18n.ForGuild(i.GuildID).Sprintf("unknown error occurred"))
 	}
 	editMessage(s, i, err.Error(), MessageOptions{
 		IsError: true,
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrInvalidURL is returned for URLs that aren't http or https, or whose
+	// host can't be resolved.
+	ErrInvalidURL = errors.New("invalid webhook URL")
+	// ErrForbiddenHost is returned for URLs that point at the bot's own
+	// machine or network rather than the internet.
+	ErrForbiddenHost = errors.New("webhook URL points at a private address")
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, private in all but name
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// allowedAddr reports whether deliveries may connect to addr. Tests swap it
+// to deliver to their local servers.
+var allowedAddr = isPublicAddr
+
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// ValidateURL checks that rawURL is an http or https URL whose host only
+// resolves to public addresses.
+func ValidateURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: failed to resolve %s", ErrInvalidURL, parsed.Hostname())
+	}
+	for _, addr := range addrs {
+		if !allowedAddr(addr) {
+			return ErrForbiddenHost
+		}
+	}
+
+	return nil
+}
+
+// checkDialAddress refuses connections to addresses ValidateURL would have
+// refused. The host may resolve differently by the time an event is sent, so
+// the address actually dialed is what counts.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse dial address: %w", err)
+	}
+	if !allowedAddr(addrPort.Addr()) {
+		return ErrForbiddenHost
+	}
+	return nil
+}
+
+func newDialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: checkDialAddress,
+	}
+}
@@ -0,0 +1,153 @@
+// Package webhook delivers signed JSON events to the URLs guilds registered,
+// so other tools can react to what happens in a competition.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"misclicked-events/internal/utils"
+	"net/http"
+	"time"
+)
+
+const (
+	// SignatureHeader holds "sha256=" and the hex HMAC-SHA256 of the body,
+	// keyed with the hook's secret.
+	SignatureHeader = "X-Misclicked-Signature"
+	// EventHeader holds the event name, the same as in the body.
+	EventHeader = "X-Misclicked-Event"
+	// DeliveryHeader holds the ID of the delivery, it stays the same over
+	// retries so receivers can ignore duplicates.
+	DeliveryHeader = "X-Misclicked-Delivery"
+)
+
+// retryDelays is how long to wait before each retry of a failed delivery
+var retryDelays = []time.Duration{10 * time.Second, time.Minute, 5 * time.Minute, 30 * time.Minute}
+
+// client only connects to public addresses, without a proxy in between that
+// would connect for it, and doesn't follow redirects so a receiver can't send
+// deliveries on to somewhere ValidateURL wouldn't allow.
+var client = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext:         newDialer().DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Hook is a URL to deliver events to and the secret to sign them with.
+type Hook struct {
+	URL    string
+	Secret string
+}
+
+// Payload is the body of every delivery.
+type Payload struct {
+	ID      string    `json:"id"`
+	Event   string    `json:"event"`
+	GuildID string    `json:"guildId"`
+	SentAt  time.Time `json:"sentAt"`
+	Data    any       `json:"data"`
+}
+
+// NewSecret makes a random secret to sign deliveries with.
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Sign returns the signature header value of body for the secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send delivers the event to every hook in the background, retrying failed
+// deliveries a few times before giving up.
+func Send(hooks []Hook, guildID, event string, data any) {
+	if len(hooks) == 0 {
+		return
+	}
+
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		utils.LogError("Error generating webhook delivery ID", err)
+		return
+	}
+	id := hex.EncodeToString(idBytes)
+
+	body, err := json.Marshal(Payload{
+		ID:      id,
+		Event:   event,
+		GuildID: guildID,
+		SentAt:  time.Now(),
+		Data:    data,
+	})
+	if err != nil {
+		utils.LogError("Error marshalling webhook payload", err)
+		return
+	}
+
+	for _, hook := range hooks {
+		go deliverWithRetries(hook, event, id, body)
+	}
+}
+
+func deliverWithRetries(hook Hook, event, deliveryID string, body []byte) {
+	for attempt := 0; ; attempt++ {
+		retry, err := deliver(hook, event, deliveryID, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt == len(retryDelays) {
+			utils.LogError(fmt.Sprintf("Giving up on %s webhook to %s after %d attempts", event, hook.URL, attempt+1), err)
+			return
+		}
+		time.Sleep(retryDelays[attempt])
+	}
+}
+
+// deliver posts the body once. It reports whether a failed delivery is
+// worth retrying: the receiver being down or busy is, a rejection isn't.
+func deliver(hook Hook, event, deliveryID string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "misclicked-events")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(SignatureHeader, Sign(hook.Secret, body))
+
+	resp, err := client.Do(req)
+	if errors.Is(err, ErrForbiddenHost) {
+		return false, fmt.Errorf("failed to send request: %w", err)
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retry, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+}
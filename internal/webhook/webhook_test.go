@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync/atomic"
+	"testing"
+)
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://93.184.216.34/hook", nil},
+		{"ftp://93.184.216.34/hook", ErrInvalidURL},
+		{"not a url", ErrInvalidURL},
+		{"https:///hook", ErrInvalidURL},
+		{"http://127.0.0.1:8080/hook", ErrForbiddenHost},
+		{"http://localhost/hook", ErrForbiddenHost},
+		{"http://[::1]/hook", ErrForbiddenHost},
+		{"http://[::ffff:127.0.0.1]/hook", ErrForbiddenHost},
+		{"http://10.0.0.5/hook", ErrForbiddenHost},
+		{"http://192.168.1.1/hook", ErrForbiddenHost},
+		{"http://169.254.169.254/latest/meta-data", ErrForbiddenHost},
+		{"http://100.64.0.1/hook", ErrForbiddenHost},
+		{"http://0.0.0.0/hook", ErrForbiddenHost},
+	}
+
+	for _, tt := range tests {
+		err := ValidateURL(context.Background(), tt.url)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ValidateURL(%q) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+}
+
+func TestDeliverRefusesPrivateAddresses(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer ts.Close()
+
+	// The URL could have resolved to a public address when it was added
+	retry, err := deliver(Hook{URL: ts.URL, Secret: "secret"}, "test", "1", []byte("{}"))
+	if !errors.Is(err, ErrForbiddenHost) || retry {
+		t.Errorf("deliver = %v, %v, want a forbidden host without retrying", retry, err)
+	}
+	if hits.Load() != 0 {
+		t.Error("the local server got the delivery")
+	}
+}
+
+func TestDeliverDoesNotFollowRedirects(t *testing.T) {
+	allowedAddr = func(netip.Addr) bool { return true }
+	t.Cleanup(func() { allowedAddr = isPublicAddr })
+
+	var targetHits atomic.Int32
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		targetHits.Add(1)
+	}))
+	defer target.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	retry, err := deliver(Hook{URL: redirect.URL, Secret: "secret"}, "test", "1", []byte("{}"))
+	if err == nil || retry {
+		t.Errorf("deliver = %v, %v, want a failed delivery without retrying", retry, err)
+	}
+	if targetHits.Load() != 0 {
+		t.Error("the redirect was followed")
+	}
+}